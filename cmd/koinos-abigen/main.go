@@ -0,0 +1,383 @@
+// koinos-abigen generates typed Go wrappers for a contract from an ABI description
+//
+// Usage:
+//
+//	koinos-abigen -abi kcs4.abi.json -out kcs4_abi.go
+//
+// The ABI description is JSON:
+//
+//	{
+//	  "name": "Kcs4",
+//	  "package": "token",
+//	  "imports": {"kcs4": "github.com/koinos/koinos-proto-golang/v2/koinos/standards/kcs4"},
+//	  "entry_points": [
+//	    {"name": "balance_of", "argument": "kcs4.BalanceOfArguments", "result": "kcs4.BalanceOfResult", "read_only": true},
+//	    {"name": "transfer", "argument": "kcs4.TransferArguments", "result": "kcs4.TransferResult"}
+//	  ]
+//	}
+//
+// Entry point IDs default to the first four bytes of the sha256 of the entry point name and
+// may be given explicitly as "id": "0x27f576ca".
+//
+// Packages the wrappers depend on, such as integration itself, cannot import them. With
+// -entries, only the entry point IDs of the ABI files given as arguments are generated into
+// -package, prefixed with the contract name and unexported:
+//
+//	koinos-abigen -entries -package integration -out entries_abi.go name_service/name_service.abi.json
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ABI describes a contract's entry points
+type ABI struct {
+	Name        string            `json:"name"`
+	Package     string            `json:"package"`
+	Imports     map[string]string `json:"imports"`
+	EntryPoints []*EntryPoint     `json:"entry_points"`
+}
+
+// EntryPoint describes a single contract entry point
+type EntryPoint struct {
+	Name     string `json:"name"`
+	ID       string `json:"id"`
+	Argument string `json:"argument"`
+	Result   string `json:"result"`
+	ReadOnly bool   `json:"read_only"`
+
+	GoName  string `json:"-"`
+	IDValue uint32 `json:"-"`
+}
+
+type importSpec struct {
+	Alias string
+	Path  string
+}
+
+const fileTemplate = `// Code generated by koinos-abigen from {{ .Source }}. DO NOT EDIT.
+
+package {{ .ABI.Package }}
+
+import (
+	"koinos-integration-tests/integration"
+{{ range .Imports }}
+	{{ .Alias }} "{{ .Path }}"
+{{- end }}
+)
+
+// {{ .ABI.Name }} entry points
+const (
+	{{- range .ABI.EntryPoints }}
+	{{ .GoName }}Entry uint32 = {{ printf "0x%08x" .IDValue }}
+	{{- end }}
+)
+
+func init() {
+	{{- range .ABI.EntryPoints }}
+	integration.RegisterEntryPoint({{ .GoName }}Entry, "{{ .Name }}")
+	{{- end }}
+}
+
+// {{ .Type }} is a typed wrapper around the {{ .ABI.Name }} contract ABI
+type {{ .Type }} struct {
+	Client  integration.Client
+	Address []byte
+}
+
+// New{{ .Type }} returns a {{ .Type }} for the contract at address
+func New{{ .Type }}(client integration.Client, address []byte) *{{ .Type }} {
+	return &{{ .Type }}{Client: client, Address: address}
+}
+
+func (c *{{ .Type }}) operation(entryPoint uint32, args proto.Message) (*protocol.Operation, error) {
+	argBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocol.Operation{
+		Op: &protocol.Operation_CallContract{
+			CallContract: &protocol.CallContractOperation{
+				ContractId: c.Address,
+				EntryPoint: entryPoint,
+				Args:       argBytes,
+			},
+		},
+	}, nil
+}
+{{- if .HasRead }}
+
+func (c *{{ .Type }}) read(entryPoint uint32, args proto.Message, result proto.Message) error {
+	argBytes, err := proto.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := integration.ReadContract(c.Client, argBytes, c.Address, entryPoint)
+	if err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(resp.GetResult(), result)
+}
+{{- end }}
+{{- if .HasWrite }}
+
+func (c *{{ .Type }}) submit(op *protocol.Operation, keys []*util.KoinosKey) (*protocol.BlockReceipt, error) {
+	opts := make([]integration.TransactionOption, len(keys))
+	for i, key := range keys {
+		opts[i] = integration.WithSigner(key)
+	}
+
+	transaction, err := integration.CreateTransactionWith(c.Client, []*protocol.Operation{op}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return integration.CreateBlockWith(c.Client, []*protocol.Transaction{transaction})
+}
+{{- end }}
+{{- range .ABI.EntryPoints }}
+{{- if .ReadOnly }}
+
+// {{ .GoName }} reads {{ .Name }}
+func (c *{{ $.Type }}) {{ .GoName }}(args *{{ .Argument }}) (*{{ .Result }}, error) {
+	result := &{{ .Result }}{}
+	if err := c.read({{ .GoName }}Entry, args, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+{{- end }}
+
+// {{ .GoName }}Operation returns an operation calling {{ .Name }}
+func (c *{{ $.Type }}) {{ .GoName }}Operation(args *{{ .Argument }}) (*protocol.Operation, error) {
+	return c.operation({{ .GoName }}Entry, args)
+}
+{{- if not .ReadOnly }}
+
+// {{ .GoName }} calls {{ .Name }} in a transaction signed by keys and produces a block containing it.
+// The first key pays for the transaction.
+func (c *{{ $.Type }}) {{ .GoName }}(args *{{ .Argument }}, keys ...*util.KoinosKey) (*protocol.BlockReceipt, error) {
+	op, err := c.{{ .GoName }}Operation(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.submit(op, keys)
+}
+{{- end }}
+{{- end }}
+`
+
+const entriesTemplate = `// Code generated by koinos-abigen from {{ .Sources }}. DO NOT EDIT.
+
+package {{ .Package }}
+{{- range .ABIs }}
+
+// {{ .Name }} entry points
+const (
+	{{- $prefix := unexported .Name }}
+	{{- range .EntryPoints }}
+	{{ $prefix }}{{ .GoName }}Entry uint32 = {{ printf "0x%08x" .IDValue }}
+	{{- end }}
+)
+{{- end }}
+`
+
+func main() {
+	abiPath := flag.String("abi", "", "ABI description file")
+	outPath := flag.String("out", "", "Output Go file, defaults to stdout")
+	entries := flag.Bool("entries", false, "Generate only the entry point IDs of the ABI files given as arguments")
+	pkg := flag.String("package", "", "Package of the entry point IDs generated with -entries")
+	flag.Parse()
+
+	var src []byte
+	var err error
+
+	switch {
+	case *entries:
+		if len(*pkg) == 0 || flag.NArg() == 0 {
+			fmt.Fprintln(os.Stderr, "koinos-abigen: -entries requires -package and ABI files")
+			os.Exit(2)
+		}
+
+		src, err = generateEntries(*pkg, flag.Args())
+	case len(*abiPath) > 0:
+		src, err = generate(*abiPath)
+	default:
+		fmt.Fprintln(os.Stderr, "koinos-abigen: -abi is required")
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-abigen: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if len(*outPath) == 0 {
+		os.Stdout.Write(src)
+		return
+	}
+
+	if err = os.WriteFile(*outPath, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-abigen: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func generate(abiPath string) ([]byte, error) {
+	abi, err := readABI(abiPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hasRead, hasWrite := false, false
+	for _, ep := range abi.EntryPoints {
+		if ep.ReadOnly {
+			hasRead = true
+		} else {
+			hasWrite = true
+		}
+	}
+
+	imports := []importSpec{
+		{Path: "github.com/koinos/koinos-proto-golang/v2/koinos/protocol"},
+		{Path: "google.golang.org/protobuf/proto"},
+	}
+	if hasWrite {
+		imports = append(imports, importSpec{Alias: "util", Path: "github.com/koinos/koinos-util-golang/v2"})
+	}
+	for alias, path := range abi.Imports {
+		if alias == filepath.Base(path) {
+			alias = ""
+		}
+		imports = append(imports, importSpec{Alias: alias, Path: path})
+	}
+	sort.Slice(imports, func(i, j int) bool { return imports[i].Path < imports[j].Path })
+
+	tmpl, err := template.New("abi").Parse(fileTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Source":   filepath.Base(abiPath),
+		"ABI":      abi,
+		"Type":     abi.Name + "Contract",
+		"Imports":  imports,
+		"HasRead":  hasRead,
+		"HasWrite": hasWrite,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// generateEntries generates the entry point IDs of several ABI files into pkg
+func generateEntries(pkg string, abiPaths []string) ([]byte, error) {
+	abis := make([]*ABI, len(abiPaths))
+	sources := make([]string, len(abiPaths))
+
+	for i, path := range abiPaths {
+		abi, err := readABI(path)
+		if err != nil {
+			return nil, err
+		}
+
+		abis[i] = abi
+		sources[i] = filepath.Base(path)
+	}
+
+	tmpl, err := template.New("entries").Funcs(template.FuncMap{"unexported": unexported}).Parse(entriesTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Sources": strings.Join(sources, ", "),
+		"Package": pkg,
+		"ABIs":    abis,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// readABI reads an ABI file, resolving the Go names and IDs of its entry points
+func readABI(abiPath string) (*ABI, error) {
+	data, err := os.ReadFile(abiPath)
+	if err != nil {
+		return nil, err
+	}
+
+	abi := &ABI{}
+	if err = json.Unmarshal(data, abi); err != nil {
+		return nil, fmt.Errorf("%s: %w", abiPath, err)
+	}
+
+	if len(abi.Name) == 0 || len(abi.Package) == 0 {
+		return nil, errors.New("name and package are required")
+	}
+
+	for _, ep := range abi.EntryPoints {
+		if len(ep.Name) == 0 || len(ep.Argument) == 0 || len(ep.Result) == 0 {
+			return nil, fmt.Errorf("entry point %q requires a name, argument and result", ep.Name)
+		}
+
+		ep.GoName = camelCase(ep.Name)
+
+		if len(ep.ID) > 0 {
+			id, err := strconv.ParseUint(ep.ID, 0, 32)
+			if err != nil {
+				return nil, fmt.Errorf("entry point %s: %w", ep.Name, err)
+			}
+			ep.IDValue = uint32(id)
+		} else {
+			sum := sha256.Sum256([]byte(ep.Name))
+			ep.IDValue = binary.BigEndian.Uint32(sum[:4])
+		}
+	}
+
+	return abi, nil
+}
+
+func unexported(name string) string {
+	if len(name) == 0 {
+		return name
+	}
+
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if len(part) > 0 {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	out, err := generate("testdata/example.abi.json")
+	require.NoError(t, err)
+	src := strings.Join(strings.Fields(string(out)), " ")
+
+	// IDs default to the first four bytes of sha256(name)
+	require.Contains(t, src, "TransferEntry uint32 = 0x27f576ca")
+	require.Contains(t, src, "PreBlockEntry uint32 = 0x531d5d4e")
+	require.Contains(t, src, "func (c *ExampleContract) BalanceOf(args *kcs4.BalanceOfArguments) (*kcs4.BalanceOfResult, error)")
+	require.Contains(t, src, "func (c *ExampleContract) Transfer(args *kcs4.TransferArguments, keys ...*util.KoinosKey) (*protocol.BlockReceipt, error)")
+	require.NotContains(t, src, "func (c *ExampleContract) BalanceOf(args *kcs4.BalanceOfArguments, keys")
+}
+
+// TestGeneratedUpToDate checks that the checked in wrappers match their ABI files
+func TestGeneratedUpToDate(t *testing.T) {
+	abis, err := filepath.Glob("../../integration/*/*.abi.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, abis)
+
+	for _, abi := range abis {
+		src, err := generate(abi)
+		require.NoError(t, err)
+
+		out := abi[:len(abi)-len(".abi.json")] + "_abi.go"
+		expected, err := os.ReadFile(out)
+		require.NoError(t, err)
+		require.Equal(t, string(expected), string(src), "%s is out of date, run go generate", out)
+	}
+}
+
+func TestGenerateEntries(t *testing.T) {
+	out, err := generateEntries("example", []string{"testdata/example.abi.json"})
+	require.NoError(t, err)
+	src := strings.Join(strings.Fields(string(out)), " ")
+
+	require.Contains(t, src, "package example")
+	require.Contains(t, src, "exampleTransferEntry uint32 = 0x27f576ca")
+	require.Contains(t, src, "examplePreBlockEntry uint32 = 0x531d5d4e")
+	require.NotContains(t, src, "import")
+}
+
+// TestEntriesUpToDate checks the entry point IDs the integration package cannot import from the wrappers
+func TestEntriesUpToDate(t *testing.T) {
+	src, err := generateEntries("integration", []string{
+		"../../integration/name_service/name_service.abi.json",
+		"../../integration/get_contract_metadata/get_contract_metadata.abi.json",
+		"../../integration/token/koin.abi.json",
+		"../../integration/resources/resources.abi.json",
+	})
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile("../../integration/entries_abi.go")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(src), "integration/entries_abi.go is out of date, run go generate")
+}
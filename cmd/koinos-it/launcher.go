@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Launcher starts and stops the cluster of a suite. The environment holds the suite's host ports.
+type Launcher interface {
+	Up(ctx context.Context, suite *Suite, env []string, output io.Writer) error
+	Logs(ctx context.Context, suite *Suite, env []string, output io.Writer) error
+	Down(ctx context.Context, suite *Suite, env []string, output io.Writer) error
+}
+
+// TestFunc runs the tests of a suite against its cluster, writing their output
+type TestFunc func(ctx context.Context, suite *Suite, env []string, output io.Writer) error
+
+// composeLauncher runs the cluster of each suite as its own docker compose project
+type composeLauncher struct {
+	command []string
+	config  string
+	prefix  string
+}
+
+func (c *composeLauncher) run(ctx context.Context, suite *Suite, env []string, output io.Writer, args ...string) error {
+	project := strings.ToLower(fmt.Sprintf("%s-%s", c.prefix, suite.Name))
+	args = append([]string{"-p", project, "-f", c.config, "-f", composeFile}, args...)
+
+	cmd := exec.CommandContext(ctx, c.command[0], append(c.command[1:], args...)...)
+	cmd.Dir = suite.Dir
+	cmd.Env = env
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s: %w", strings.Join(c.command, " "), strings.Join(args, " "), err)
+	}
+
+	return nil
+}
+
+// Up starts the services of the suite
+func (c *composeLauncher) Up(ctx context.Context, suite *Suite, env []string, output io.Writer) error {
+	return c.run(ctx, suite, env, output, "up", "-d")
+}
+
+// Logs writes the logs of the services of the suite
+func (c *composeLauncher) Logs(ctx context.Context, suite *Suite, env []string, output io.Writer) error {
+	return c.run(ctx, suite, env, output, "logs", "--no-color", "--timestamps")
+}
+
+// Down stops the services of the suite and removes their volumes
+func (c *composeLauncher) Down(ctx context.Context, suite *Suite, env []string, output io.Writer) error {
+	return c.run(ctx, suite, env, output, "down", "-v")
+}
+
+// goTest returns a TestFunc running go test in the suite directory
+func goTest(timeout time.Duration) TestFunc {
+	return func(ctx context.Context, suite *Suite, env []string, output io.Writer) error {
+		cmd := exec.CommandContext(ctx, "go", "test", "-count=1", "-timeout", timeout.String(), "-v", "./...")
+		cmd.Dir = suite.Dir
+		cmd.Env = env
+		cmd.Stdout = output
+		cmd.Stderr = output
+
+		return cmd.Run()
+	}
+}
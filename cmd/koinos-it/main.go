@@ -0,0 +1,104 @@
+// koinos-it runs the integration test suites, each against its own docker compose cluster
+//
+// Usage:
+//
+//	koinos-it [-parallel 4] [-json summary.json] [-junit junit.xml] [suite ...]
+//
+// Every directory under -root with a docker-compose.yml is a suite, unless suites are named on
+// the command line. Each suite's cluster runs as its own compose project, publishing its services
+// on free host ports passed to the compose file and the tests through the KOINOS_*_PORT
+// environment variables, so up to -parallel suites run at once.
+//
+// The output of each suite is written to -artifacts/<suite>/test.log. When a suite fails, the logs
+// of its services are captured to -artifacts/<suite>/services.log before the cluster is stopped.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func main() {
+	root := flag.String("root", "tests", "Directory of the test suites")
+	config := flag.String("config", "node_config/docker-compose.config.yml", "Compose file shared by every suite")
+	compose := flag.String("compose", "docker compose", "Command running docker compose")
+	parallel := flag.Int("parallel", 2, "Number of suites run at once")
+	artifacts := flag.String("artifacts", "artifacts", "Directory of the test output and service logs")
+	timeout := flag.Duration("timeout", 30*time.Minute, "Timeout of the tests of each suite")
+	jsonPath := flag.String("json", "", "Write a JSON summary to the file")
+	junitPath := flag.String("junit", "", "Write a JUnit summary to the file")
+	flag.Parse()
+
+	suites, err := discover(*root, flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-it: %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	configPath, err := filepath.Abs(*config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-it: %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	runner := &Runner{
+		Launcher: &composeLauncher{
+			command: strings.Fields(*compose),
+			config:  configPath,
+			prefix:  fmt.Sprintf("koinos-it-%d", os.Getpid()),
+		},
+		Test:      goTest(*timeout),
+		Parallel:  *parallel,
+		Artifacts: *artifacts,
+		Logf: func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	summary := runner.Run(ctx, suites)
+
+	if err := summary.Write(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-it: %s\n", err.Error())
+	}
+
+	if err := writeFile(*jsonPath, summary.WriteJSON); err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-it: %s\n", err.Error())
+	}
+
+	if err := writeFile(*junitPath, summary.WriteJUnit); err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-it: %s\n", err.Error())
+	}
+
+	if summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// writeFile writes the file at path with write, unless path is empty
+func writeFile(path string, write func(w io.Writer) error) error {
+	if len(path) == 0 {
+		return nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
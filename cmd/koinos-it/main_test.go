@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubLauncher records the clusters it starts and stops, failing to start the suites in failUp
+type stubLauncher struct {
+	mu     sync.Mutex
+	failUp map[string]bool
+	calls  []string
+	envs   map[string][]string
+}
+
+func (l *stubLauncher) record(call string, suite *Suite, env []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls = append(l.calls, call+" "+suite.Name)
+	if l.envs == nil {
+		l.envs = make(map[string][]string)
+	}
+	l.envs[suite.Name] = env
+}
+
+func (l *stubLauncher) Up(ctx context.Context, suite *Suite, env []string, output io.Writer) error {
+	l.record("up", suite, env)
+	if l.failUp[suite.Name] {
+		return fmt.Errorf("port is already allocated")
+	}
+
+	return nil
+}
+
+func (l *stubLauncher) Logs(ctx context.Context, suite *Suite, env []string, output io.Writer) error {
+	l.record("logs", suite, env)
+	_, err := fmt.Fprintf(output, "logs of %s\n", suite.Name)
+	return err
+}
+
+func (l *stubLauncher) Down(ctx context.Context, suite *Suite, env []string, output io.Writer) error {
+	l.record("down", suite, env)
+	return nil
+}
+
+func (l *stubLauncher) count(call string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := 0
+	for _, c := range l.calls {
+		if strings.HasPrefix(c, call+" ") {
+			n++
+		}
+	}
+
+	return n
+}
+
+func writeSuite(t *testing.T, root string, name string, compose string) {
+	require.NoError(t, os.MkdirAll(filepath.Join(root, name), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, name, composeFile), []byte(compose), 0o644))
+}
+
+func envValue(env []string, name string) string {
+	for _, entry := range env {
+		if k, v, ok := strings.Cut(entry, "="); ok && k == name {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	writeSuite(t, root, "koin", `ports: ["${KOINOS_AMQP_PORT:-25673}:5672", "${KOINOS_JSONRPC_PORT:-28080}:8080"]`)
+	writeSuite(t, root, "cluster", `ports: ["${KOINOS_JSONRPC_PORT:-28080}:8080", "${KOINOS_JSONRPC_PORT_1:-28081}:8080"]`)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "scenarios"), 0o755))
+
+	suites, err := discover(root, nil)
+	require.NoError(t, err)
+	require.Len(t, suites, 2)
+	require.Equal(t, "cluster", suites[0].Name)
+	require.Equal(t, []string{"KOINOS_JSONRPC_PORT", "KOINOS_JSONRPC_PORT_1"}, suites[0].PortVars)
+	require.Equal(t, []string{"KOINOS_AMQP_PORT", "KOINOS_JSONRPC_PORT"}, suites[1].PortVars)
+
+	suites, err = discover(root, []string{"koin"})
+	require.NoError(t, err)
+	require.Len(t, suites, 1)
+
+	_, err = discover(root, []string{"missing"})
+	require.Error(t, err)
+}
+
+func TestRunner(t *testing.T) {
+	root := t.TempDir()
+	compose := `ports: ["${KOINOS_AMQP_PORT:-25673}:5672", "${KOINOS_JSONRPC_PORT:-28080}:8080"]`
+	for _, name := range []string{"a", "b", "c", "d", "broken", "failing"} {
+		writeSuite(t, root, name, compose)
+	}
+
+	suites, err := discover(root, nil)
+	require.NoError(t, err)
+
+	launcher := &stubLauncher{failUp: map[string]bool{"broken": true}}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+
+	runner := &Runner{
+		Launcher:  launcher,
+		Parallel:  2,
+		Artifacts: filepath.Join(t.TempDir(), "artifacts"),
+		Logf:      t.Logf,
+		Test: func(ctx context.Context, suite *Suite, env []string, output io.Writer) error {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			defer func() {
+				mu.Lock()
+				running--
+				mu.Unlock()
+			}()
+
+			time.Sleep(20 * time.Millisecond)
+			fmt.Fprintf(output, "--- output of %s\n", suite.Name)
+
+			if suite.Name == "failing" {
+				return fmt.Errorf("exit status 1")
+			}
+
+			return nil
+		},
+	}
+
+	summary := runner.Run(context.Background(), suites)
+
+	t.Logf("Running at most the parallelism limit at once")
+	require.Equal(t, 2, maxRunning)
+
+	t.Logf("Reporting every suite in order")
+	require.Equal(t, 4, summary.Passed)
+	require.Equal(t, 2, summary.Failed)
+	names := make([]string, len(summary.Results))
+	for i, result := range summary.Results {
+		names[i] = result.Name
+	}
+	require.Equal(t, []string{"a", "b", "broken", "c", "d", "failing"}, names)
+
+	broken, failing := summary.Results[2], summary.Results[5]
+	require.Equal(t, StatusError, broken.Status)
+	require.Contains(t, broken.Error, "starting services")
+	require.Equal(t, StatusFailed, failing.Status)
+
+	t.Logf("Passing the allocated ports through the environment")
+	for _, result := range summary.Results {
+		require.Len(t, result.Ports, 2)
+		require.NotEqual(t, result.Ports["KOINOS_AMQP_PORT"], result.Ports["KOINOS_JSONRPC_PORT"])
+		require.Equal(t, fmt.Sprint(result.Ports["KOINOS_AMQP_PORT"]), envValue(launcher.envs[result.Name], "KOINOS_AMQP_PORT"))
+		require.Equal(t, "1", envValue(launcher.envs[result.Name], "KOINOS_REQUIRE_ENDPOINTS"))
+	}
+
+	t.Logf("Never allocating a port twice while it is in use")
+	var allocator portAllocator
+	first, err := allocator.allocate(10)
+	require.NoError(t, err)
+	second, err := allocator.allocate(10)
+	require.NoError(t, err)
+
+	distinct := make(map[int]struct{})
+	for _, port := range append(first, second...) {
+		distinct[port] = struct{}{}
+	}
+	require.Len(t, distinct, 20)
+
+	t.Logf("Stopping every cluster, and capturing logs only on failure")
+	require.Equal(t, len(suites), launcher.count("down"))
+	require.Equal(t, 2, launcher.count("logs"))
+
+	logs, err := os.ReadFile(failing.Logs)
+	require.NoError(t, err)
+	require.Equal(t, "logs of failing\n", string(logs))
+	require.Empty(t, summary.Results[0].Logs)
+
+	output, err := os.ReadFile(failing.Output)
+	require.NoError(t, err)
+	require.Contains(t, string(output), "--- output of failing")
+
+	t.Logf("Writing the JSON and JUnit summaries")
+	var buf bytes.Buffer
+	require.NoError(t, summary.WriteJSON(&buf))
+
+	var decoded Summary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, summary.Passed, decoded.Passed)
+	require.Equal(t, StatusFailed, decoded.Results[5].Status)
+
+	buf.Reset()
+	require.NoError(t, summary.WriteJUnit(&buf))
+
+	var junit junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &junit))
+	require.Len(t, junit.Suites, 1)
+	require.Equal(t, 6, junit.Suites[0].Tests)
+	require.Equal(t, 1, junit.Suites[0].Failures)
+	require.Equal(t, 1, junit.Suites[0].Errors)
+	require.NotNil(t, junit.Suites[0].Cases[5].Failure)
+	require.Contains(t, junit.Suites[0].Cases[5].Failure.Output, "--- output of failing")
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxFailureLines is how many lines of test output a JUnit failure includes
+const maxFailureLines = 100
+
+// Summary is the outcome of a run
+type Summary struct {
+	Passed   int           `json:"passed"`
+	Failed   int           `json:"failed"`
+	Duration time.Duration `json:"duration"`
+	Results  []*Result     `json:"results"`
+}
+
+func newSummary(results []*Result, duration time.Duration) *Summary {
+	summary := &Summary{Duration: duration, Results: results}
+	for _, result := range results {
+		if result.Status == StatusPassed {
+			summary.Passed++
+		} else {
+			summary.Failed++
+		}
+	}
+
+	return summary
+}
+
+// Write writes the summary as text
+func (s *Summary) Write(w io.Writer) error {
+	lines := make([]string, 0, len(s.Results)+1)
+	for _, result := range s.Results {
+		line := fmt.Sprintf("%-7s %s (%s)", strings.ToUpper(result.Status), result.Name, result.Duration.Round(time.Second))
+		if len(result.Logs) > 0 {
+			line += fmt.Sprintf(", service logs in %s", result.Logs)
+		}
+
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, fmt.Sprintf("%d passed, %d failed in %s", s.Passed, s.Failed, s.Duration.Round(time.Second)))
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// WriteJSON writes the summary as JSON
+func (s *Summary) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name       `xml:"testsuites"`
+	Suites  []junitTestRun `xml:"testsuite"`
+}
+
+type junitTestRun struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Output  string `xml:",chardata"`
+}
+
+// WriteJUnit writes the summary as a JUnit report, with a test case for each suite
+func (s *Summary) WriteJUnit(w io.Writer) error {
+	run := junitTestRun{Name: "koinos-it", Tests: len(s.Results), Time: s.Duration.Seconds()}
+
+	for _, result := range s.Results {
+		testCase := junitTestCase{Name: result.Name, ClassName: "tests", Time: result.Duration.Seconds()}
+
+		switch result.Status {
+		case StatusFailed:
+			run.Failures++
+			testCase.Failure = &junitFailure{Message: result.Error, Output: tail(result.Output, maxFailureLines)}
+		case StatusError:
+			run.Errors++
+			testCase.Error = &junitFailure{Message: result.Error, Output: tail(result.Output, maxFailureLines)}
+		}
+
+		run.Cases = append(run.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestRun{run}}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// tail returns the last n lines of the file at path, or nothing if it cannot be read
+func tail(path string, n int) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(string(contents), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return strings.Join(lines, "\n")
+}
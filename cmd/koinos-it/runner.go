@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// testLog and servicesLog are the artifacts of a suite
+	testLog     = "test.log"
+	servicesLog = "services.log"
+
+	// maxPortAttempts bounds the search for ports not already allocated to a running suite
+	maxPortAttempts = 1000
+
+	// downTimeout bounds stopping a cluster, which happens even when the run is interrupted
+	downTimeout = 2 * time.Minute
+)
+
+// Suite statuses
+const (
+	StatusPassed = "passed"
+	StatusFailed = "failed"
+	StatusError  = "error"
+)
+
+// Result is the outcome of a suite
+type Result struct {
+	Name     string         `json:"name"`
+	Status   string         `json:"status"`
+	Duration time.Duration  `json:"duration"`
+	Ports    map[string]int `json:"ports,omitempty"`
+	Error    string         `json:"error,omitempty"`
+	// Output is the path of the test output, and Logs the path of the service logs captured on failure
+	Output string `json:"output,omitempty"`
+	Logs   string `json:"logs,omitempty"`
+}
+
+// Runner runs suites concurrently, each against its own cluster on its own host ports
+type Runner struct {
+	Launcher  Launcher
+	Test      TestFunc
+	Parallel  int
+	Artifacts string
+
+	Logf func(format string, args ...interface{})
+
+	ports portAllocator
+}
+
+func (r *Runner) logf(format string, args ...interface{}) {
+	if r.Logf != nil {
+		r.Logf(format, args...)
+	}
+}
+
+// Run runs the suites, at most Parallel at a time, returning their results in order
+func (r *Runner) Run(ctx context.Context, suites []*Suite) *Summary {
+	parallel := r.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	start := time.Now()
+	results := make([]*Result, len(suites))
+	slots := make(chan struct{}, parallel)
+
+	var wg sync.WaitGroup
+	for i, suite := range suites {
+		wg.Add(1)
+		go func(i int, suite *Suite) {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = &Result{Name: suite.Name, Status: StatusError, Error: ctx.Err().Error()}
+				return
+			}
+			defer func() { <-slots }()
+
+			results[i] = r.runSuite(ctx, suite)
+		}(i, suite)
+	}
+	wg.Wait()
+
+	return newSummary(results, time.Since(start))
+}
+
+// runSuite starts the cluster of the suite, runs its tests and stops the cluster
+func (r *Runner) runSuite(ctx context.Context, suite *Suite) *Result {
+	start := time.Now()
+	result := &Result{Name: suite.Name, Ports: make(map[string]int)}
+
+	fail := func(status string, err error) *Result {
+		result.Status = status
+		result.Error = err.Error()
+		result.Duration = time.Since(start)
+		r.logf("%s %s (%s): %s", status, suite.Name, result.Duration.Round(time.Second), err)
+		return result
+	}
+
+	dir := filepath.Join(r.Artifacts, suite.Name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fail(StatusError, err)
+	}
+
+	ports, err := r.ports.allocate(len(suite.PortVars))
+	if err != nil {
+		return fail(StatusError, fmt.Errorf("allocating ports: %w", err))
+	}
+	defer r.ports.release(ports)
+
+	// The cluster is up once the tests run, so an unreachable endpoint fails the suite
+	env := append(os.Environ(), "KOINOS_REQUIRE_ENDPOINTS=1")
+	for i, name := range suite.PortVars {
+		result.Ports[name] = ports[i]
+		env = append(env, fmt.Sprintf("%s=%d", name, ports[i]))
+	}
+
+	result.Output = filepath.Join(dir, testLog)
+	output, err := os.Create(result.Output)
+	if err != nil {
+		return fail(StatusError, err)
+	}
+	defer output.Close()
+
+	defer func() {
+		downCtx, cancel := context.WithTimeout(context.Background(), downTimeout)
+		defer cancel()
+
+		if err := r.Launcher.Down(downCtx, suite, env, output); err != nil {
+			r.logf("%s: stopping services: %s", suite.Name, err)
+		}
+	}()
+
+	r.logf("%s: starting services on ports %v", suite.Name, result.Ports)
+	if err := r.Launcher.Up(ctx, suite, env, output); err != nil {
+		r.captureLogs(suite, env, result)
+		return fail(StatusError, fmt.Errorf("starting services: %w", err))
+	}
+
+	r.logf("%s: running tests", suite.Name)
+	if err := r.Test(ctx, suite, env, output); err != nil {
+		r.captureLogs(suite, env, result)
+		return fail(StatusFailed, err)
+	}
+
+	result.Status = StatusPassed
+	result.Duration = time.Since(start)
+	r.logf("%s %s (%s)", StatusPassed, suite.Name, result.Duration.Round(time.Second))
+	return result
+}
+
+// captureLogs writes the service logs of the suite to its artifacts
+func (r *Runner) captureLogs(suite *Suite, env []string, result *Result) {
+	path := filepath.Join(r.Artifacts, suite.Name, servicesLog)
+
+	logs, err := os.Create(path)
+	if err != nil {
+		r.logf("%s: capturing service logs: %s", suite.Name, err)
+		return
+	}
+	defer logs.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), downTimeout)
+	defer cancel()
+
+	if err := r.Launcher.Logs(ctx, suite, env, logs); err != nil {
+		r.logf("%s: capturing service logs: %s", suite.Name, err)
+	}
+
+	result.Logs = path
+}
+
+// portAllocator hands out free host ports, never the same port to two running suites
+type portAllocator struct {
+	mu   sync.Mutex
+	used map[int]struct{}
+}
+
+func (p *portAllocator) allocate(n int) ([]int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.used == nil {
+		p.used = make(map[int]struct{})
+	}
+
+	ports := make([]int, 0, n)
+	for attempts := 0; len(ports) < n; attempts++ {
+		port, err := freePort()
+		if err == nil && attempts >= maxPortAttempts {
+			err = fmt.Errorf("no free port after %d attempts", attempts)
+		}
+
+		if err != nil {
+			for _, port := range ports {
+				delete(p.used, port)
+			}
+			return nil, err
+		}
+
+		if _, ok := p.used[port]; ok {
+			continue
+		}
+
+		p.used[port] = struct{}{}
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
+
+func (p *portAllocator) release(ports []int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, port := range ports {
+		delete(p.used, port)
+	}
+}
+
+// freePort returns a port no process is listening on
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
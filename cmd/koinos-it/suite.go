@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// composeFile is the docker-compose file of a suite, relative to its directory
+const composeFile = "docker-compose.yml"
+
+// portVarPattern matches a host port published by a compose file, such as ${KOINOS_AMQP_PORT:-25673}
+var portVarPattern = regexp.MustCompile(`\$\{(KOINOS_[A-Z0-9_]*PORT[A-Z0-9_]*):-[0-9]+\}`)
+
+// Suite is a directory of tests run against its own docker-compose cluster
+type Suite struct {
+	Name string
+	Dir  string
+	// PortVars are the environment variables of the host ports the cluster publishes
+	PortVars []string
+}
+
+// discover returns the suites under root, or only the named suites if any are given
+func discover(root string, names []string) ([]*Suite, error) {
+	if len(names) == 0 {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+
+			if _, err := os.Stat(filepath.Join(root, entry.Name(), composeFile)); err == nil {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+
+	suites := make([]*Suite, 0, len(names))
+	for _, name := range names {
+		suite, err := loadSuite(filepath.Join(root, name))
+		if err != nil {
+			return nil, err
+		}
+
+		suites = append(suites, suite)
+	}
+
+	return suites, nil
+}
+
+// loadSuite reads the port variables of the suite in dir
+func loadSuite(dir string) (*Suite, error) {
+	compose, err := os.ReadFile(filepath.Join(dir, composeFile))
+	if err != nil {
+		return nil, fmt.Errorf("suite %s: %w", filepath.Base(dir), err)
+	}
+
+	vars := make(map[string]struct{})
+	for _, match := range portVarPattern.FindAllSubmatch(compose, -1) {
+		vars[string(match[1])] = struct{}{}
+	}
+
+	suite := &Suite{Name: filepath.Base(dir), Dir: dir}
+	for name := range vars {
+		suite.PortVars = append(suite.PortVars, name)
+	}
+	sort.Strings(suite.PortVars)
+
+	return suite, nil
+}
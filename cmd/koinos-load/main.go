@@ -0,0 +1,400 @@
+// koinos-load submits transactions to a node at a target rate and reports throughput and latency
+//
+// Usage:
+//
+//	koinos-load -url http://localhost:28080/ -accounts 10 -transactions 1000 -rate 100 -mix transfer=8,call=2
+//
+// The accounts are derived from -seed and funded by minting koin in a block signed by the genesis
+// key. Every transaction is built and signed before submission starts, then submitted by -workers
+// goroutines at up to -rate transactions per second. The transactions of an account are submitted
+// in nonce order by a single worker.
+//
+// The mix weights the kinds of transactions:
+//
+//	transfer  transfers 1 koin satoshi to the next account
+//	call      calls balance_of on the koin contract
+//	upload    uploads the contract given by -upload to the account
+//
+// Against a node without a block producer, -produce creates a block of the pending transactions
+// at the given interval.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/token"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+)
+
+const (
+	// maxBlockTransactions is the most pending transactions included in a produced block
+	maxBlockTransactions = 2000
+)
+
+// config configures a load run
+type config struct {
+	Accounts     int
+	Transactions int
+	Rate         float64
+	Workers      int
+	Mix          *mix
+	Fund         uint64
+	RcLimit      uint64
+	Upload       string
+	Seed         string
+	Produce      time.Duration
+	Wait         time.Duration
+	PollInterval time.Duration
+
+	Logf func(format string, args ...interface{})
+}
+
+func (c *config) logf(format string, args ...interface{}) {
+	if c.Logf != nil {
+		c.Logf(format, args...)
+	}
+}
+
+// job is a signed transaction of an account
+type job struct {
+	account     int
+	transaction *protocol.Transaction
+}
+
+func main() {
+	transport := flag.String("transport", "jsonrpc", "Transport to the node, jsonrpc or mq")
+	url := flag.String("url", "", fmt.Sprintf("Node url, defaults to %s or %s as published for the tests", integration.JSONRPCURL(0), integration.AMQPURL()))
+	accounts := flag.Int("accounts", 10, "Number of accounts submitting transactions")
+	transactions := flag.Int("transactions", 1000, "Number of transactions to submit")
+	rate := flag.Float64("rate", 0, "Transactions submitted per second, 0 for no limit")
+	workers := flag.Int("workers", 4, "Number of goroutines submitting transactions")
+	mixFlag := flag.String("mix", "transfer", "Weighted transaction kinds, such as transfer=8,call=2")
+	fundValue := flag.Uint64("fund", 10000000000, "Koin satoshis minted to each account")
+	rcLimit := flag.Uint64("rc", 10000000, "Rc limit of each transaction")
+	upload := flag.String("upload", "", "Contract wasm uploaded by upload transactions")
+	seed := flag.String("seed", "koinos-load", "Seed the accounts are derived from")
+	produce := flag.Duration("produce", 0, "Interval to produce blocks of pending transactions, 0 to rely on the node")
+	wait := flag.Duration("wait", time.Minute, "How long to wait for accepted transactions to be included")
+	jsonOut := flag.Bool("json", false, "Write the report as JSON")
+	flag.Parse()
+
+	m, err := parseMix(*mixFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-load: %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	client, err := newClient(*transport, *url)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-load: %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	cfg := &config{
+		Accounts:     *accounts,
+		Transactions: *transactions,
+		Rate:         *rate,
+		Workers:      *workers,
+		Mix:          m,
+		Fund:         *fundValue,
+		RcLimit:      *rcLimit,
+		Upload:       *upload,
+		Seed:         *seed,
+		Produce:      *produce,
+		Wait:         *wait,
+		PollInterval: 100 * time.Millisecond,
+		Logf: func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := run(ctx, client, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-load: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if *jsonOut {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	} else {
+		err = report.Write(os.Stdout)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "koinos-load: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func newClient(transport string, url string) (integration.Client, error) {
+	switch transport {
+	case "jsonrpc":
+		if len(url) == 0 {
+			url = integration.JSONRPCURL(0)
+		}
+
+		return integration.NewJSONRPCClient(url), nil
+	case "mq":
+		if len(url) == 0 {
+			url = integration.AMQPURL()
+		}
+
+		return integration.NewKoinosMQClient(url), nil
+	}
+
+	return nil, fmt.Errorf("unknown transport %s", transport)
+}
+
+// run funds the accounts, signs the transactions, submits them and waits for their inclusion
+func run(ctx context.Context, client integration.Client, cfg *config) (*Report, error) {
+	client = integration.WithContext(client, ctx)
+
+	if cfg.Accounts < 1 || cfg.Workers < 1 {
+		return nil, fmt.Errorf("expected at least one account and worker")
+	}
+
+	// The interval between submissions is at least a nanosecond
+	if cfg.Rate > float64(time.Second) {
+		return nil, fmt.Errorf("rate %g is above %d transactions per second", cfg.Rate, time.Second)
+	}
+
+	var bytecode []byte
+	if cfg.Mix.has(UploadKind) {
+		if len(cfg.Upload) == 0 {
+			return nil, fmt.Errorf("upload transactions require -upload")
+		}
+
+		var err error
+		if bytecode, err = os.ReadFile(cfg.Upload); err != nil {
+			return nil, err
+		}
+	}
+
+	keyring := integration.NewKeyring(cfg.Seed)
+
+	gen := &generator{bytecode: bytecode}
+	for i := 0; i < cfg.Accounts; i++ {
+		key, err := keyring.Key(fmt.Sprintf("load-%d", i))
+		if err != nil {
+			return nil, err
+		}
+
+		gen.accounts = append(gen.accounts, key)
+	}
+
+	gen.koin = token.GetKoinToken(client)
+	gen.kcs4 = token.NewKcs4Contract(client, gen.koin.Address())
+
+	cfg.logf("Funding %d accounts", cfg.Accounts)
+	if err := fund(client, gen.koin, gen.accounts, cfg.Fund); err != nil {
+		return nil, fmt.Errorf("funding accounts: %w", err)
+	}
+
+	cfg.logf("Signing %d transactions", cfg.Transactions)
+	jobs, err := sign(client, cfg, gen)
+	if err != nil {
+		return nil, fmt.Errorf("signing transactions: %w", err)
+	}
+
+	headInfo, err := integration.GetHeadInfo(client)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTracker()
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	if cfg.Produce > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			produce(runCtx, client, cfg)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		watch(runCtx, client, headInfo.HeadTopology.GetHeight(), t, cfg.PollInterval)
+	}()
+
+	cfg.logf("Submitting %d transactions", len(jobs))
+	start := time.Now()
+	submit(ctx, client, cfg, jobs, t)
+	submitDuration := time.Since(start)
+
+	cfg.logf("Waiting for inclusion")
+	select {
+	case <-t.done:
+	case <-time.After(cfg.Wait):
+		cfg.logf("Timed out waiting for inclusion")
+	case <-ctx.Done():
+	}
+
+	cancel()
+	wg.Wait()
+
+	return t.report(submitDuration, time.Since(start)), nil
+}
+
+// sign builds and signs every transaction of the run
+func sign(client integration.Client, cfg *config, gen *generator) ([]*job, error) {
+	builders := make([]*integration.TransactionBuilder, len(gen.accounts))
+	for i, account := range gen.accounts {
+		builder, err := integration.NewTransactionBuilder(client, integration.WithSigner(account), integration.WithRcLimit(cfg.RcLimit))
+		if err != nil {
+			return nil, err
+		}
+
+		builders[i] = builder
+	}
+
+	// The mix is drawn from the seed, so runs with the same flags submit the same transactions
+	hash := fnv.New64a()
+	hash.Write([]byte(cfg.Seed))
+	r := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	jobs := make([]*job, cfg.Transactions)
+	for i := range jobs {
+		account := i % len(gen.accounts)
+
+		ops, err := gen.operations(cfg.Mix.pick(r), account)
+		if err != nil {
+			return nil, err
+		}
+
+		transaction, err := builders[account].Build(ops)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs[i] = &job{account: account, transaction: transaction}
+	}
+
+	return jobs, nil
+}
+
+// submit submits the jobs at up to the configured rate. Each account's jobs go to the same worker.
+func submit(ctx context.Context, client integration.Client, cfg *config, jobs []*job, t *tracker) {
+	workers := cfg.Workers
+	if workers > cfg.Accounts {
+		workers = cfg.Accounts
+	}
+
+	var wg sync.WaitGroup
+	queues := make([]chan *job, workers)
+	for i := range queues {
+		queues[i] = make(chan *job, len(jobs))
+
+		wg.Add(1)
+		go func(queue chan *job) {
+			defer wg.Done()
+
+			for j := range queue {
+				start := time.Now()
+				t.send(j.transaction.Id, start)
+
+				if _, err := integration.SubmitTransaction(client, j.transaction); err != nil {
+					t.fail(j.transaction.Id, err)
+				} else {
+					t.accept(time.Since(start))
+				}
+			}
+		}(queues[i])
+	}
+
+	var limiter <-chan time.Time
+	if cfg.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.Rate))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+dispatch:
+	for _, j := range jobs {
+		if limiter != nil {
+			select {
+			case <-limiter:
+			case <-ctx.Done():
+				break dispatch
+			}
+		}
+
+		queues[j.account%workers] <- j
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+
+	wg.Wait()
+	t.finish()
+}
+
+// watch records every block after height until ctx is done
+func watch(ctx context.Context, client integration.Client, height uint64, t *tracker, interval time.Duration) {
+	for {
+		headInfo, err := integration.GetHeadInfo(client)
+		if err == nil && headInfo.HeadTopology.GetHeight() > height {
+			head := headInfo.HeadTopology
+			blocks, err := integration.GetBlocksByHeight(client, head.GetId(), height+1, uint32(head.GetHeight()-height), true, false)
+			if err == nil {
+				for _, item := range blocks.BlockItems {
+					t.block(item.BlockHeight, item.Block.GetTransactions())
+					height = item.BlockHeight
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// produce creates a block of the pending transactions at each interval until ctx is done
+func produce(ctx context.Context, client integration.Client, cfg *config) {
+	ticker := time.NewTicker(cfg.Produce)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pending, err := integration.GetPendingTransactions(client, maxBlockTransactions)
+		if err != nil || len(pending) == 0 {
+			continue
+		}
+
+		transactions := make([]*protocol.Transaction, len(pending))
+		for i, pendingTrx := range pending {
+			transactions[i] = pendingTrx.Transaction
+		}
+
+		if _, err = integration.CreateBlockWith(client, transactions); err != nil && ctx.Err() == nil {
+			cfg.logf("Producing block: %s", err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"koinos-integration-tests/integration/fake"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMix(t *testing.T) {
+	m, err := parseMix("transfer=8, call=2,upload=0")
+	require.NoError(t, err)
+	require.Equal(t, []string{CallKind, TransferKind}, m.kinds)
+	require.Equal(t, []int{2, 8}, m.weights)
+	require.False(t, m.has(UploadKind))
+
+	counts := make(map[string]int)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		counts[m.pick(r)]++
+	}
+	require.InDelta(t, 800, counts[TransferKind], 50)
+	require.InDelta(t, 200, counts[CallKind], 50)
+
+	m, err = parseMix("call")
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, m.weights)
+
+	for _, s := range []string{"", "transfer=0", "transfer=x", "mint=1"} {
+		_, err = parseMix(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	durations := make([]time.Duration, 100)
+	for i := range durations {
+		durations[len(durations)-1-i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	latency := newLatency(durations)
+	require.Equal(t, 50*time.Millisecond, latency.P50)
+	require.Equal(t, 90*time.Millisecond, latency.P90)
+	require.Equal(t, 99*time.Millisecond, latency.P99)
+	require.Equal(t, 100*time.Millisecond, latency.Max)
+
+	require.Nil(t, newLatency(nil))
+	require.Equal(t, "n/a", newLatency(nil).String())
+}
+
+func TestRun(t *testing.T) {
+	m, err := parseMix("transfer=2,call=1")
+	require.NoError(t, err)
+
+	cfg := &config{
+		Accounts:     3,
+		Transactions: 30,
+		Workers:      2,
+		Mix:          m,
+		Fund:         1000,
+		RcLimit:      fake.RcPerOperation,
+		Seed:         t.Name(),
+		Produce:      10 * time.Millisecond,
+		Wait:         10 * time.Second,
+		PollInterval: 5 * time.Millisecond,
+		Logf:         t.Logf,
+	}
+
+	report, err := run(context.Background(), fake.NewClient(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, 30, report.Submitted)
+	require.Equal(t, 30, report.Accepted, "errors: %v", report.Errors)
+	require.Equal(t, 30, report.Included)
+	require.Zero(t, report.Failed)
+	require.NotNil(t, report.SubmitLatency)
+	require.NotNil(t, report.InclusionLatency)
+
+	load := 0
+	for _, block := range report.Blocks {
+		load += block.Load
+	}
+	require.Equal(t, 30, load)
+
+	var out bytes.Buffer
+	require.NoError(t, report.Write(&out))
+	require.Contains(t, out.String(), "submitted 30, accepted 30, included 30, failed 0")
+
+	t.Logf("Rejecting rates above one transaction per nanosecond")
+	cfg.Rate = 2e9
+	_, err = run(context.Background(), fake.NewClient(), cfg)
+	require.EqualError(t, err, "rate 2e+09 is above 1000000000 transactions per second")
+	cfg.Rate = 0
+
+	t.Logf("Requiring a contract to upload")
+	cfg.Mix, err = parseMix("upload")
+	require.NoError(t, err)
+	_, err = run(context.Background(), fake.NewClient(), cfg)
+	require.Error(t, err)
+}
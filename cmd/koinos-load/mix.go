@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/token"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/standards/kcs4"
+	util "github.com/koinos/koinos-util-golang/v2"
+)
+
+// Transaction kinds of a mix
+const (
+	TransferKind = "transfer"
+	CallKind     = "call"
+	UploadKind   = "upload"
+)
+
+// mix is a weighted set of transaction kinds
+type mix struct {
+	kinds   []string
+	weights []int
+	total   int
+}
+
+// parseMix parses a mix such as "transfer=8,call=2". A kind without a weight has weight 1.
+func parseMix(s string) (*mix, error) {
+	weights := make(map[string]int)
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		kind, weight := entry, 1
+		if k, w, ok := strings.Cut(entry, "="); ok {
+			n, err := strconv.Atoi(w)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid weight %q of %s", w, k)
+			}
+
+			kind, weight = k, n
+		}
+
+		switch kind {
+		case TransferKind, CallKind, UploadKind:
+		default:
+			return nil, fmt.Errorf("unknown transaction kind %s", kind)
+		}
+
+		weights[kind] += weight
+	}
+
+	m := &mix{}
+	for kind, weight := range weights {
+		if weight > 0 {
+			m.kinds = append(m.kinds, kind)
+		}
+	}
+	sort.Strings(m.kinds)
+
+	for _, kind := range m.kinds {
+		m.weights = append(m.weights, weights[kind])
+		m.total += weights[kind]
+	}
+
+	if m.total == 0 {
+		return nil, fmt.Errorf("mix %q has no transactions", s)
+	}
+
+	return m, nil
+}
+
+// has returns true if the mix includes kind
+func (m *mix) has(kind string) bool {
+	for _, k := range m.kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pick chooses a kind with probability proportional to its weight
+func (m *mix) pick(r *rand.Rand) string {
+	n := r.Intn(m.total)
+	for i, weight := range m.weights {
+		if n < weight {
+			return m.kinds[i]
+		}
+
+		n -= weight
+	}
+
+	return m.kinds[len(m.kinds)-1]
+}
+
+// generator creates the operations of each kind of transaction
+type generator struct {
+	koin     *token.Token
+	kcs4     *token.Kcs4Contract
+	accounts []*util.KoinosKey
+	bytecode []byte
+}
+
+// operations returns the operations of a transaction of kind sent by account
+func (g *generator) operations(kind string, account int) ([]*protocol.Operation, error) {
+	from := g.accounts[account].AddressBytes()
+
+	switch kind {
+	case TransferKind:
+		to := g.accounts[(account+1)%len(g.accounts)].AddressBytes()
+		op, err := g.koin.TransferOperation(from, to, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*protocol.Operation{op}, nil
+
+	case CallKind:
+		op, err := g.kcs4.BalanceOfOperation(&kcs4.BalanceOfArguments{Owner: from})
+		if err != nil {
+			return nil, err
+		}
+
+		return []*protocol.Operation{op}, nil
+
+	case UploadKind:
+		if g.bytecode == nil {
+			return nil, fmt.Errorf("upload transactions require -upload")
+		}
+
+		return []*protocol.Operation{{
+			Op: &protocol.Operation_UploadContract{
+				UploadContract: &protocol.UploadContractOperation{
+					ContractId: from,
+					Bytecode:   g.bytecode,
+				},
+			},
+		}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown transaction kind %s", kind)
+}
+
+// fund mints value koin to every account in a single block
+func fund(client integration.Client, koin *token.Token, accounts []*util.KoinosKey, value uint64) error {
+	ops := make([]*protocol.Operation, len(accounts))
+	for i, account := range accounts {
+		op, err := koin.MintOperation(account.AddressBytes(), value)
+		if err != nil {
+			return err
+		}
+
+		ops[i] = op
+	}
+
+	koinKey, err := integration.GetKey(integration.Koin)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := integration.CreateTransactionWith(client, ops, integration.WithSigner(koinKey))
+	if err != nil {
+		return err
+	}
+
+	_, err = integration.CreateBlockWith(client, []*protocol.Transaction{transaction})
+	return err
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report summarizes a load run
+type Report struct {
+	Submitted int `json:"submitted"`
+	Accepted  int `json:"accepted"`
+	Included  int `json:"included"`
+	Failed    int `json:"failed"`
+
+	Duration          time.Duration `json:"duration"`
+	SubmitLatency     *Latency      `json:"submit_latency"`
+	InclusionLatency  *Latency      `json:"inclusion_latency"`
+	AcceptedPerSecond float64       `json:"accepted_per_second"`
+	IncludedPerSecond float64       `json:"included_per_second"`
+
+	// Errors counts the submission errors by message
+	Errors map[string]int `json:"errors,omitempty"`
+	Blocks []*BlockFill   `json:"blocks"`
+}
+
+// Latency is the distribution of a set of durations
+type Latency struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// BlockFill is the number of transactions in a block, and how many of them were submitted by the run
+type BlockFill struct {
+	Height       uint64 `json:"height"`
+	Transactions int    `json:"transactions"`
+	Load         int    `json:"load"`
+}
+
+// newLatency returns the distribution of durations, or nil if there are none
+func newLatency(durations []time.Duration) *Latency {
+	if len(durations) == 0 {
+		return nil
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &Latency{
+		P50: percentile(sorted, 50),
+		P90: percentile(sorted, 90),
+		P99: percentile(sorted, 99),
+		Max: sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest rank percentile p of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+// String returns the percentiles of the latency
+func (l *Latency) String() string {
+	if l == nil {
+		return "n/a"
+	}
+
+	return fmt.Sprintf("p50 %s p90 %s p99 %s max %s", l.P50, l.P90, l.P99, l.Max)
+}
+
+// Write writes the report as text
+func (r *Report) Write(w io.Writer) error {
+	lines := []string{
+		fmt.Sprintf("submitted %d, accepted %d, included %d, failed %d in %s", r.Submitted, r.Accepted, r.Included, r.Failed, r.Duration.Round(time.Millisecond)),
+		fmt.Sprintf("throughput: %.1f accepted/s, %.1f included/s", r.AcceptedPerSecond, r.IncludedPerSecond),
+		fmt.Sprintf("submit latency: %s", r.SubmitLatency),
+		fmt.Sprintf("inclusion latency: %s", r.InclusionLatency),
+	}
+
+	if len(r.Errors) > 0 {
+		messages := make([]string, 0, len(r.Errors))
+		for message := range r.Errors {
+			messages = append(messages, message)
+		}
+		sort.Strings(messages)
+
+		lines = append(lines, "errors:")
+		for _, message := range messages {
+			lines = append(lines, fmt.Sprintf("  %d x %s", r.Errors[message], message))
+		}
+	}
+
+	lines = append(lines, "blocks:")
+	for _, block := range r.Blocks {
+		lines = append(lines, fmt.Sprintf("  height %d: %d transactions, %d from load", block.Height, block.Transactions, block.Load))
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
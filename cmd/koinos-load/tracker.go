@@ -0,0 +1,133 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+)
+
+// tracker records the outcome of every transaction submitted in a run
+type tracker struct {
+	mu sync.Mutex
+
+	// inFlight holds the submission time of transactions that are neither failed nor included
+	inFlight map[string]time.Time
+	finished bool
+	done     chan struct{}
+
+	submitted, accepted, included, failed int
+	errors                                map[string]int
+	submitLatency, inclusionLatency       []time.Duration
+	blocks                                []*BlockFill
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		inFlight: make(map[string]time.Time),
+		done:     make(chan struct{}),
+		errors:   make(map[string]int),
+	}
+}
+
+// send records the submission of a transaction
+func (t *tracker) send(id []byte, start time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.submitted++
+	t.inFlight[string(id)] = start
+}
+
+// accept records a transaction accepted after latency
+func (t *tracker) accept(latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.accepted++
+	t.submitLatency = append(t.submitLatency, latency)
+}
+
+// fail records a rejected transaction
+func (t *tracker) fail(id []byte, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failed++
+	t.errors[err.Error()]++
+	delete(t.inFlight, string(id))
+	t.checkDone()
+}
+
+// block records the transactions of a block, including those of the run
+func (t *tracker) block(height uint64, transactions []*protocol.Transaction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	fill := &BlockFill{Height: height, Transactions: len(transactions)}
+
+	for _, transaction := range transactions {
+		if start, ok := t.inFlight[string(transaction.Id)]; ok {
+			fill.Load++
+			t.included++
+			t.inclusionLatency = append(t.inclusionLatency, now.Sub(start))
+			delete(t.inFlight, string(transaction.Id))
+		}
+	}
+
+	t.blocks = append(t.blocks, fill)
+	t.checkDone()
+}
+
+// finish records that every transaction was submitted
+func (t *tracker) finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.finished = true
+	t.checkDone()
+}
+
+// checkDone closes done once every transaction is submitted and none are in flight
+func (t *tracker) checkDone() {
+	if t.finished && len(t.inFlight) == 0 {
+		select {
+		case <-t.done:
+		default:
+			close(t.done)
+		}
+	}
+}
+
+// report summarizes the run. Throughput of acceptance is over the submission, and of inclusion
+// over the whole run.
+func (t *tracker) report(submitDuration time.Duration, duration time.Duration) *Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r := &Report{
+		Submitted:        t.submitted,
+		Accepted:         t.accepted,
+		Included:         t.included,
+		Failed:           t.failed,
+		Duration:         duration,
+		SubmitLatency:    newLatency(t.submitLatency),
+		InclusionLatency: newLatency(t.inclusionLatency),
+		Blocks:           t.blocks,
+	}
+
+	if len(t.errors) > 0 {
+		r.Errors = t.errors
+	}
+
+	if submitDuration > 0 {
+		r.AcceptedPerSecond = float64(t.accepted) / submitDuration.Seconds()
+	}
+
+	if duration > 0 {
+		r.IncludedPerSecond = float64(t.included) / duration.Seconds()
+	}
+
+	return r
+}
@@ -0,0 +1,527 @@
+package bootstrap
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/claim"
+	"koinos-integration-tests/integration/get_contract_metadata"
+	"koinos-integration-tests/integration/governance"
+	"koinos-integration-tests/integration/name_service"
+	"koinos-integration-tests/integration/pob"
+	"koinos-integration-tests/integration/resources"
+	"koinos-integration-tests/integration/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	name_service_pb "github.com/koinos/koinos-proto-golang/v2/koinos/contracts/name-service"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultContractDir is where contracts are found relative to a test directory
+	DefaultContractDir = "../../contracts"
+
+	// DefaultGenesisKoin is minted to the genesis key before RC is charged in KOIN
+	DefaultGenesisKoin uint64 = 100000000000 // 1,000.00000000 KOIN
+
+	// maxUploadBytes keeps each block's uploads under the 409,600 byte disk storage limit of
+	// the chain's default get_resource_limits thunk, which applies until the resources
+	// contract overrides it. The margin covers the names and overrides registered with them.
+	maxUploadBytes = 300000
+)
+
+type override struct {
+	role       int
+	entryPoint uint32
+	systemCall chain.SystemCallId
+}
+
+type balance struct {
+	address []byte
+	value   uint64
+}
+
+type component struct {
+	role       int
+	name       string
+	file       string
+	depends    []int
+	overrides  []override
+	authorizes bool
+
+	// late overrides change RC or authority and are applied once everything else is in place
+	late []override
+}
+
+var components = map[int]*component{
+	integration.NameService: {
+		role: integration.NameService,
+		name: "name_service",
+		file: "name_service.wasm",
+		overrides: []override{
+			{integration.NameService, name_service.GetNameEntry, chain.SystemCallId_get_contract_name},
+			{integration.NameService, name_service.GetAddressEntry, chain.SystemCallId_get_contract_address},
+		},
+	},
+	integration.GetContractMetadata: {
+		role:    integration.GetContractMetadata,
+		name:    "get_contract_metadata",
+		file:    "get_contract_metadata.wasm",
+		depends: []int{integration.NameService},
+		overrides: []override{
+			{integration.GetContractMetadata, get_contract_metadata.GetContractMetadataEntry, chain.SystemCallId_get_contract_metadata},
+		},
+	},
+	integration.Koin: {
+		role:    integration.Koin,
+		name:    "koin",
+		file:    "koin.wasm",
+		depends: []int{integration.NameService, integration.GetContractMetadata},
+	},
+	integration.Vhp: {
+		role:    integration.Vhp,
+		name:    "vhp",
+		file:    "vhp.wasm",
+		depends: []int{integration.NameService, integration.GetContractMetadata},
+	},
+	integration.Pob: {
+		role:    integration.Pob,
+		name:    "pob",
+		file:    "pob.wasm",
+		depends: []int{integration.Koin, integration.Vhp},
+	},
+	integration.Claim: {
+		role:    integration.Claim,
+		name:    "claim",
+		file:    "claim.wasm",
+		depends: []int{integration.Koin},
+	},
+	integration.Governance: {
+		role:       integration.Governance,
+		name:       "governance",
+		file:       "governance.wasm",
+		depends:    []int{integration.Koin},
+		authorizes: true,
+		late: []override{
+			{integration.Governance, governance.BlockCallbackEntry, chain.SystemCallId_pre_block_callback},
+			{integration.Governance, governance.CheckSystemAuthorityEntry, chain.SystemCallId_check_system_authority},
+		},
+	},
+	integration.Resources: {
+		role:    integration.Resources,
+		name:    "resources",
+		file:    "resources.wasm",
+		depends: []int{integration.Koin},
+		late: []override{
+			{integration.Resources, resources.GetResourceLimitsEntry, chain.SystemCallId_get_resource_limits},
+			{integration.Resources, resources.ConsumeBlockResourcesEntry, chain.SystemCallId_consume_block_resources},
+			{integration.Koin, token.GetAccountRcEntry, chain.SystemCallId_get_account_rc},
+			{integration.Koin, token.ConsumeAccountRcEntry, chain.SystemCallId_consume_account_rc},
+		},
+	},
+}
+
+// Builder collects the system contracts a test needs and applies them to a chain
+//
+//	c := bootstrap.New(client).WithKoin().WithVhp().WithPob().Apply(t)
+//
+// Dependencies are added automatically, so WithPob implies KOIN, VHP, the name service
+// and get_contract_metadata.
+type Builder struct {
+	client      integration.Client
+	contractDir string
+	genesisKoin uint64
+	selected    map[int]struct{}
+	balances    []balance
+	noKoinRc    bool
+}
+
+// Chain is a bootstrapped chain with keys and wrappers for each applied contract
+type Chain struct {
+	Client integration.Client
+
+	Genesis *util.KoinosKey
+	Keys    map[int]*util.KoinosKey
+
+	NameService *name_service.NameService
+	Koin        *token.Token
+	Vhp         *token.Token
+	Pob         *pob.PobContract
+	Governance  *governance.Governance
+	Claim       *claim.Claim
+	Resources   *resources.ResourcesContract
+}
+
+// New creates a Builder for the client
+func New(client integration.Client) *Builder {
+	return &Builder{
+		client:      client,
+		contractDir: DefaultContractDir,
+		genesisKoin: DefaultGenesisKoin,
+		selected:    make(map[int]struct{}),
+	}
+}
+
+// WithContractDir sets the directory containing the contract wasm files
+func (b *Builder) WithContractDir(dir string) *Builder {
+	b.contractDir = dir
+	return b
+}
+
+// WithGenesisKoin sets the KOIN minted to the genesis key when RC is charged in KOIN
+func (b *Builder) WithGenesisKoin(value uint64) *Builder {
+	b.genesisKoin = value
+	return b
+}
+
+// WithNameService adds the name service contract
+func (b *Builder) WithNameService() *Builder {
+	return b.with(integration.NameService)
+}
+
+// WithGetContractMetadata adds the get_contract_metadata contract
+func (b *Builder) WithGetContractMetadata() *Builder {
+	return b.with(integration.GetContractMetadata)
+}
+
+// WithKoin adds the KOIN contract
+func (b *Builder) WithKoin() *Builder {
+	return b.with(integration.Koin)
+}
+
+// WithVhp adds the VHP contract
+func (b *Builder) WithVhp() *Builder {
+	return b.with(integration.Vhp)
+}
+
+// WithPob adds the PoB contract. Block signature processing is left to the test to enable.
+func (b *Builder) WithPob() *Builder {
+	return b.with(integration.Pob)
+}
+
+// WithClaim adds the claim contract
+func (b *Builder) WithClaim() *Builder {
+	return b.with(integration.Claim)
+}
+
+// WithGovernance adds the governance contract and its pre_block and system authority overrides
+func (b *Builder) WithGovernance() *Builder {
+	return b.with(integration.Governance)
+}
+
+// WithResources adds the resources contract and charges RC in KOIN
+func (b *Builder) WithResources() *Builder {
+	return b.with(integration.Resources)
+}
+
+// WithKoinBalance adds the KOIN contract and mints value to address once the contracts are
+// uploaded, before RC, resource and system authority overrides are applied
+func (b *Builder) WithKoinBalance(address []byte, value uint64) *Builder {
+	b.balances = append(b.balances, balance{address: address, value: value})
+	return b.with(integration.Koin)
+}
+
+// WithoutKoinRc leaves get_account_rc and consume_account_rc to the chain when the resources
+// contract is added, so RC is not charged in KOIN and no KOIN is minted to the genesis key
+func (b *Builder) WithoutKoinRc() *Builder {
+	b.noKoinRc = true
+	return b
+}
+
+func (b *Builder) with(role int) *Builder {
+	b.selected[role] = struct{}{}
+	return b
+}
+
+// order returns the selected components and their dependencies, dependencies first
+func (b *Builder) order() []*component {
+	visited := make(map[int]struct{})
+	ordered := make([]*component, 0)
+
+	var visit func(role int)
+	visit = func(role int) {
+		if _, ok := visited[role]; ok {
+			return
+		}
+		visited[role] = struct{}{}
+
+		c := components[role]
+		for _, dep := range c.depends {
+			visit(dep)
+		}
+		ordered = append(ordered, c)
+	}
+
+	// Visit in key order so the resulting block layout is deterministic
+	for role := integration.Genesis; role <= integration.GetContractMetadata; role++ {
+		if _, ok := b.selected[role]; ok {
+			visit(role)
+		}
+	}
+
+	return ordered
+}
+
+// Apply uploads the contracts, registers names and system calls, and returns the Chain
+func (b *Builder) Apply(t *testing.T) *Chain {
+	client := integration.ForTest(t, b.client)
+	integration.AwaitChain(t, client)
+
+	genesisKey, err := integration.GetKey(integration.Genesis)
+	integration.NoError(t, err)
+
+	nameServiceKey, err := integration.GetKey(integration.NameService)
+	integration.NoError(t, err)
+
+	c := &Chain{Client: b.client, Genesis: genesisKey, Keys: map[int]*util.KoinosKey{integration.Genesis: genesisKey}}
+
+	getKey := func(role int) *util.KoinosKey {
+		if key, ok := c.Keys[role]; ok {
+			return key
+		}
+
+		key, err := integration.GetKey(role)
+		integration.NoError(t, err)
+		c.Keys[role] = key
+		return key
+	}
+
+	ordered := b.order()
+
+	// Uploads are grouped into as few blocks as the disk storage limit allows. Each block
+	// also carries one genesis transaction registering the contracts it uploaded.
+	var transactions []*protocol.Transaction
+	var systemOps []*protocol.Operation
+	var names []string
+	uploadBytes := 0
+
+	flush := func() {
+		if len(transactions) == 0 {
+			return
+		}
+
+		tx, err := integration.CreateTransactionWith(client, systemOps, integration.WithSigner(genesisKey))
+		integration.NoError(t, err)
+
+		t.Logf("Uploading %s", strings.Join(names, ", "))
+		receipt, err := integration.CreateBlockWith(client, append(transactions, tx))
+		integration.NoError(t, err)
+		requireApplied(t, receipt)
+
+		transactions, systemOps, names, uploadBytes = nil, nil, nil, 0
+	}
+
+	var late []override
+	for _, comp := range ordered {
+		key := getKey(comp.role)
+
+		wasm, err := integration.BytesFromFile(filepath.Join(b.contractDir, comp.file), 512000)
+		integration.NoError(t, err)
+
+		if uploadBytes > 0 && uploadBytes+len(wasm) > maxUploadBytes {
+			flush()
+		}
+
+		upload := &protocol.Operation{
+			Op: &protocol.Operation_UploadContract{
+				UploadContract: &protocol.UploadContractOperation{
+					ContractId:                       key.AddressBytes(),
+					Bytecode:                         wasm,
+					AuthorizesTransactionApplication: comp.authorizes,
+				},
+			},
+		}
+
+		tx, err := integration.CreateTransactionWith(client, []*protocol.Operation{upload}, integration.WithSigner(key))
+		integration.NoError(t, err)
+
+		transactions = append(transactions, tx)
+		names = append(names, comp.name)
+		uploadBytes += len(wasm)
+
+		setRecord, err := setRecordOperation(nameServiceKey, comp.name, key)
+		integration.NoError(t, err)
+
+		systemOps = append(systemOps, setSystemContractOperation(key), setRecord)
+		for _, o := range comp.overrides {
+			systemOps = append(systemOps, setSystemCallOperation(getKey(o.role), o))
+		}
+
+		for _, o := range comp.late {
+			if b.noKoinRc && (o.systemCall == chain.SystemCallId_get_account_rc || o.systemCall == chain.SystemCallId_consume_account_rc) {
+				continue
+			}
+			late = append(late, o)
+		}
+	}
+	flush()
+
+	if len(b.balances) > 0 {
+		b.mintBalances(t, getKey(integration.Koin))
+	}
+
+	if len(late) > 0 {
+		b.applyLate(t, c, late, getKey)
+	}
+
+	for role := range b.selected {
+		getKey(role)
+	}
+
+	if _, ok := c.Keys[integration.NameService]; ok {
+		c.NameService = name_service.GetNameService(b.client)
+	}
+	if _, ok := c.Keys[integration.Koin]; ok {
+		c.Koin = token.GetKoinToken(b.client)
+	}
+	if _, ok := c.Keys[integration.Vhp]; ok {
+		c.Vhp = token.GetVhpToken(b.client)
+	}
+	if key, ok := c.Keys[integration.Pob]; ok {
+		c.Pob = pob.NewPobContract(b.client, key.AddressBytes())
+	}
+	if _, ok := c.Keys[integration.Governance]; ok {
+		c.Governance = governance.GetGovernance(b.client)
+	}
+	if _, ok := c.Keys[integration.Claim]; ok {
+		c.Claim = claim.NewClaim(b.client)
+	}
+	if key, ok := c.Keys[integration.Resources]; ok {
+		c.Resources = resources.NewResourcesContract(b.client, key.AddressBytes())
+	}
+
+	return c
+}
+
+// mintBalances mints the KOIN balances in a single block
+func (b *Builder) mintBalances(t *testing.T, koinKey *util.KoinosKey) {
+	client := integration.ForTest(t, b.client)
+	koin := token.GetKoinToken(client)
+
+	ops := make([]*protocol.Operation, 0, len(b.balances))
+	for _, balance := range b.balances {
+		op, err := koin.MintOperation(balance.address, balance.value)
+		integration.NoError(t, err)
+		ops = append(ops, op)
+	}
+
+	tx, err := integration.CreateTransactionWith(client, ops, integration.WithSigner(koinKey))
+	integration.NoError(t, err)
+
+	t.Logf("Minting KOIN balances")
+	receipt, err := integration.CreateBlockWith(client, []*protocol.Transaction{tx})
+	integration.NoError(t, err)
+	requireApplied(t, receipt)
+}
+
+// applyLate applies overrides that change how RC is charged or who has system authority
+func (b *Builder) applyLate(t *testing.T, c *Chain, late []override, getKey func(int) *util.KoinosKey) {
+	client := integration.ForTest(t, b.client)
+
+	var transactions []*protocol.Transaction
+
+	// Once consume_account_rc is charged in KOIN the genesis key needs a balance to pay for
+	// the transaction making the change
+	for _, o := range late {
+		if o.systemCall == chain.SystemCallId_consume_account_rc && b.genesisKoin > 0 {
+			koin := token.GetKoinToken(client)
+			op, err := koin.MintOperation(c.Genesis.AddressBytes(), b.genesisKoin)
+			integration.NoError(t, err)
+
+			tx, err := integration.CreateTransactionWith(client, []*protocol.Operation{op}, integration.WithSigner(getKey(integration.Koin)))
+			integration.NoError(t, err)
+
+			transactions = append(transactions, tx)
+			break
+		}
+	}
+
+	// check_system_authority goes last so it cannot reject the other overrides
+	ops := make([]*protocol.Operation, 0, len(late))
+	var authority *protocol.Operation
+	for _, o := range late {
+		op := setSystemCallOperation(getKey(o.role), o)
+		if o.systemCall == chain.SystemCallId_check_system_authority {
+			authority = op
+			continue
+		}
+		ops = append(ops, op)
+	}
+	if authority != nil {
+		ops = append(ops, authority)
+	}
+
+	tx, err := integration.CreateTransactionWith(client, ops, integration.WithSigner(c.Genesis))
+	integration.NoError(t, err)
+
+	t.Logf("Overriding system calls")
+	receipt, err := integration.CreateBlockWith(client, append(transactions, tx))
+	integration.NoError(t, err)
+	requireApplied(t, receipt)
+}
+
+// Key returns the key for an integration key type, such as integration.Koin
+func (c *Chain) Key(role int) *util.KoinosKey {
+	return c.Keys[role]
+}
+
+func requireApplied(t *testing.T, receipt *protocol.BlockReceipt) {
+	for _, txReceipt := range receipt.TransactionReceipts {
+		if txReceipt.Reverted {
+			integration.LogBlockReceipt(t, receipt)
+			t.Fatalf("Bootstrap transaction %x reverted", txReceipt.Id)
+		}
+	}
+}
+
+func setSystemContractOperation(key *util.KoinosKey) *protocol.Operation {
+	return &protocol.Operation{
+		Op: &protocol.Operation_SetSystemContract{
+			SetSystemContract: &protocol.SetSystemContractOperation{
+				ContractId:     key.AddressBytes(),
+				SystemContract: true,
+			},
+		},
+	}
+}
+
+func setRecordOperation(nameServiceKey *util.KoinosKey, name string, key *util.KoinosKey) (*protocol.Operation, error) {
+	args, err := proto.Marshal(&name_service_pb.SetRecordArguments{
+		Name:    name,
+		Address: key.AddressBytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocol.Operation{
+		Op: &protocol.Operation_CallContract{
+			CallContract: &protocol.CallContractOperation{
+				ContractId: nameServiceKey.AddressBytes(),
+				EntryPoint: name_service.SetRecordEntry,
+				Args:       args,
+			},
+		},
+	}, nil
+}
+
+func setSystemCallOperation(key *util.KoinosKey, o override) *protocol.Operation {
+	return &protocol.Operation{
+		Op: &protocol.Operation_SetSystemCall{
+			SetSystemCall: &protocol.SetSystemCallOperation{
+				CallId: uint32(o.systemCall),
+				Target: &protocol.SystemCallTarget{
+					Target: &protocol.SystemCallTarget_SystemCallBundle{
+						SystemCallBundle: &protocol.ContractCallBundle{
+							ContractId: key.AddressBytes(),
+							EntryPoint: o.entryPoint,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package bootstrap
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	client := fake.NewClient()
+
+	c := New(client).WithPob().WithGovernance().WithResources().Apply(t)
+
+	require.NotNil(t, c.Koin)
+	require.NotNil(t, c.Vhp)
+	require.NotNil(t, c.Governance)
+	require.NotNil(t, c.NameService)
+	require.Nil(t, c.Claim)
+	require.NotNil(t, c.Pob)
+	require.Equal(t, c.Key(integration.Pob).AddressBytes(), c.Pob.Address)
+	require.NotNil(t, c.Resources)
+	require.Equal(t, c.Key(integration.Resources).AddressBytes(), c.Resources.Address)
+	require.NotNil(t, c.Key(integration.Pob))
+
+	// Two blocks of uploads followed by one block of late overrides
+	blocks := client.Blocks()
+	require.Len(t, blocks, 3)
+
+	last := blocks[len(blocks)-1]
+	require.Len(t, last.Transactions, 2, "Expected the genesis KOIN mint before the overrides")
+
+	ops := last.Transactions[1].Operations
+	require.EqualValues(t, chain.SystemCallId_check_system_authority, ops[len(ops)-1].GetSetSystemCall().CallId)
+
+	uploaded := 0
+	for _, block := range blocks[:2] {
+		for _, tx := range block.Transactions {
+			if tx.Operations[0].GetUploadContract() != nil {
+				uploaded++
+			}
+		}
+	}
+	require.Equal(t, 7, uploaded)
+}
+
+func TestApplyWithoutKoinRc(t *testing.T) {
+	client := fake.NewClient()
+
+	c := New(client).WithResources().WithoutKoinRc().WithKoinBalance([]byte{1}, 1000).Apply(t)
+	require.NotNil(t, c.Resources)
+
+	t.Logf("Minting balances in the block before the overrides")
+	blocks := client.Blocks()
+	mint := blocks[len(blocks)-2]
+	require.Len(t, mint.Transactions, 1)
+	require.Len(t, mint.Transactions[0].Operations, 1)
+	require.Equal(t, c.Key(integration.Koin).AddressBytes(), mint.Transactions[0].Operations[0].GetCallContract().GetContractId())
+
+	last := blocks[len(blocks)-1]
+	require.Len(t, last.Transactions, 1, "Expected no genesis KOIN mint")
+
+	ops := last.Transactions[0].Operations
+	require.Len(t, ops, 2)
+	require.EqualValues(t, chain.SystemCallId_get_resource_limits, ops[0].GetSetSystemCall().CallId)
+	require.EqualValues(t, chain.SystemCallId_consume_block_resources, ops[1].GetSetSystemCall().CallId)
+}
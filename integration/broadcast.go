@@ -0,0 +1,380 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	koinosmq "github.com/koinos/koinos-mq-golang"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/broadcast"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Broadcast topics of the koinos microservices
+const (
+	BlockAcceptedTopic       = "koinos.block.accept"
+	BlockIrreversibleTopic   = "koinos.block.irreversible"
+	ForkHeadsTopic           = "koinos.block.forks"
+	TransactionAcceptedTopic = "koinos.transaction.accept"
+	TransactionFailedTopic   = "koinos.transaction.fail"
+
+	// EventTopicPrefix prefixes the topics of contract events, followed by the base58 contract
+	// address and the event name
+	EventTopicPrefix = "koinos.event."
+)
+
+const (
+	// subscribeTimeout bounds how long Subscribe waits for the broadcast queues to be bound
+	subscribeTimeout = 10 * time.Second
+	// defaultSubscriptionTimeout bounds how long the Await functions wait for a broadcast
+	defaultSubscriptionTimeout = time.Minute
+)
+
+// Subscription collects the broadcasts of the node from when it was created, for tests to
+// await or inspect instead of polling the node
+type Subscription struct {
+	// Timeout bounds how long the Await functions wait for a broadcast, within the context of the
+	// subscription
+	Timeout time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu           sync.Mutex
+	changed      chan struct{}
+	blocks       []*broadcast.BlockAccepted
+	irreversible []*broadcast.BlockIrreversible
+	forkHeads    *broadcast.ForkHeads
+	transactions []*broadcast.TransactionAccepted
+	failed       []*broadcast.TransactionFailed
+	events       []*broadcast.EventParcel
+	errs         []error
+
+	// callbacks are the callbacks of each type of broadcast, by the name of its message
+	callbacks map[protoreflect.FullName][]func(proto.Message)
+}
+
+func newSubscription(ctx context.Context) *Subscription {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Subscription{
+		Timeout:   defaultSubscriptionTimeout,
+		ctx:       ctx,
+		cancel:    cancel,
+		changed:   make(chan struct{}),
+		callbacks: make(map[protoreflect.FullName][]func(proto.Message)),
+	}
+}
+
+// Subscribe binds a queue to each broadcast topic, returning once the broadcasts are being
+// collected. The subscription ends with ctx or Close.
+func (mq *MQClient) Subscribe(ctx context.Context) (*Subscription, error) {
+	s := newSubscription(ctx)
+
+	handler := koinosmq.NewRequestHandler(mq.url, 1, koinosmq.NoRetry)
+	for _, topic := range []string{BlockAcceptedTopic, BlockIrreversibleTopic, ForkHeadsTopic, TransactionAcceptedTopic, TransactionFailedTopic, EventTopicPrefix + "#"} {
+		handler.SetBroadcastHandler(topic, func(topic string, data []byte) {
+			s.deliver(topic, data)
+		})
+	}
+
+	connected := handler.Start(s.ctx)
+
+	select {
+	case <-connected:
+		return s, nil
+	case <-time.After(subscribeTimeout):
+		s.Close()
+		return nil, fmt.Errorf("subscribing to %s: timed out after %s", mq.url, subscribeTimeout)
+	case <-s.ctx.Done():
+		s.Close()
+		return nil, s.ctx.Err()
+	}
+}
+
+// SubscribeFromEnv subscribes to the broadcasts of the node under test over amqp, until the test
+// finishes. If the endpoint does not accept connections within the connect timeout, the test is
+// skipped.
+func SubscribeFromEnv(t *testing.T) *Subscription {
+	t.Helper()
+
+	endpoint := urlSetting(AMQPURLEnv, AMQPURL())
+	requireReachable(t, string(AMQP)+" broadcast", endpoint)
+
+	s, err := (&MQClient{url: endpoint}).Subscribe(TestContext(t))
+	NoError(t, err)
+
+	return s
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.cancel()
+}
+
+// deliver decodes a broadcast, calls the callbacks of its type and records it
+func (s *Subscription) deliver(topic string, data []byte) {
+	var message proto.Message
+
+	switch {
+	case topic == BlockAcceptedTopic:
+		message = &broadcast.BlockAccepted{}
+	case topic == BlockIrreversibleTopic:
+		message = &broadcast.BlockIrreversible{}
+	case topic == ForkHeadsTopic:
+		message = &broadcast.ForkHeads{}
+	case topic == TransactionAcceptedTopic:
+		message = &broadcast.TransactionAccepted{}
+	case topic == TransactionFailedTopic:
+		message = &broadcast.TransactionFailed{}
+	case strings.HasPrefix(topic, EventTopicPrefix):
+		message = &broadcast.EventParcel{}
+	default:
+		return
+	}
+
+	if err := proto.Unmarshal(data, message); err != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.errs = append(s.errs, fmt.Errorf("decoding %s: %w", topic, err))
+		s.notify()
+		return
+	}
+
+	// Callbacks run before the broadcast is recorded, so they have seen it when an Await function returns
+	s.mu.Lock()
+	callbacks := s.callbacks[message.ProtoReflect().Descriptor().FullName()]
+	s.mu.Unlock()
+
+	for _, f := range callbacks {
+		f(message)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch m := message.(type) {
+	case *broadcast.BlockAccepted:
+		s.blocks = append(s.blocks, m)
+	case *broadcast.BlockIrreversible:
+		s.irreversible = append(s.irreversible, m)
+	case *broadcast.ForkHeads:
+		s.forkHeads = m
+	case *broadcast.TransactionAccepted:
+		s.transactions = append(s.transactions, m)
+	case *broadcast.TransactionFailed:
+		s.failed = append(s.failed, m)
+	case *broadcast.EventParcel:
+		s.events = append(s.events, m)
+	}
+
+	s.notify()
+}
+
+// on registers a callback of the broadcasts of message
+func (s *Subscription) on(message proto.Message, f func(proto.Message)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := message.ProtoReflect().Descriptor().FullName()
+	s.callbacks[name] = append(s.callbacks[name], f)
+}
+
+// notify wakes the Await functions, with the lock held
+func (s *Subscription) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// OnBlockAccepted calls f with each accepted block broadcast after it is registered
+func (s *Subscription) OnBlockAccepted(f func(*broadcast.BlockAccepted)) {
+	s.on(&broadcast.BlockAccepted{}, func(m proto.Message) { f(m.(*broadcast.BlockAccepted)) })
+}
+
+// OnBlockIrreversible calls f with each irreversible block broadcast after it is registered
+func (s *Subscription) OnBlockIrreversible(f func(*broadcast.BlockIrreversible)) {
+	s.on(&broadcast.BlockIrreversible{}, func(m proto.Message) { f(m.(*broadcast.BlockIrreversible)) })
+}
+
+// OnForkHeads calls f with each fork heads broadcast after it is registered
+func (s *Subscription) OnForkHeads(f func(*broadcast.ForkHeads)) {
+	s.on(&broadcast.ForkHeads{}, func(m proto.Message) { f(m.(*broadcast.ForkHeads)) })
+}
+
+// OnTransactionAccepted calls f with each accepted transaction broadcast after it is registered
+func (s *Subscription) OnTransactionAccepted(f func(*broadcast.TransactionAccepted)) {
+	s.on(&broadcast.TransactionAccepted{}, func(m proto.Message) { f(m.(*broadcast.TransactionAccepted)) })
+}
+
+// OnTransactionFailed calls f with each failed transaction broadcast after it is registered
+func (s *Subscription) OnTransactionFailed(f func(*broadcast.TransactionFailed)) {
+	s.on(&broadcast.TransactionFailed{}, func(m proto.Message) { f(m.(*broadcast.TransactionFailed)) })
+}
+
+// OnEvent calls f with each contract event broadcast after it is registered
+func (s *Subscription) OnEvent(f func(*broadcast.EventParcel)) {
+	s.on(&broadcast.EventParcel{}, func(m proto.Message) { f(m.(*broadcast.EventParcel)) })
+}
+
+// Blocks returns the accepted blocks broadcast so far
+func (s *Subscription) Blocks() []*broadcast.BlockAccepted {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*broadcast.BlockAccepted(nil), s.blocks...)
+}
+
+// Transactions returns the accepted transactions broadcast so far
+func (s *Subscription) Transactions() []*broadcast.TransactionAccepted {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*broadcast.TransactionAccepted(nil), s.transactions...)
+}
+
+// ForkHeads returns the latest fork heads broadcast, or nil
+func (s *Subscription) ForkHeads() *broadcast.ForkHeads {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.forkHeads
+}
+
+// Events returns the contract events broadcast so far, in the order they were received
+func (s *Subscription) Events() []*broadcast.EventParcel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*broadcast.EventParcel(nil), s.events...)
+}
+
+// Err returns the first broadcast that could not be decoded, or nil
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.errs) > 0 {
+		return s.errs[0]
+	}
+
+	return nil
+}
+
+// AwaitBlock returns the first accepted block at or above height
+func (s *Subscription) AwaitBlock(height uint64) (*broadcast.BlockAccepted, error) {
+	var block *broadcast.BlockAccepted
+	err := s.await(fmt.Sprintf("block at height %d", height), func() bool {
+		for _, b := range s.blocks {
+			if b.Block.GetHeader().GetHeight() >= height {
+				block = b
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return block, err
+}
+
+// AwaitIrreversible returns the first irreversible block at or above height
+func (s *Subscription) AwaitIrreversible(height uint64) (*broadcast.BlockIrreversible, error) {
+	var block *broadcast.BlockIrreversible
+	err := s.await(fmt.Sprintf("irreversible block at height %d", height), func() bool {
+		for _, b := range s.irreversible {
+			if b.Topology.GetHeight() >= height {
+				block = b
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return block, err
+}
+
+// AwaitTransactionAccepted returns the broadcast of the transaction with id being accepted
+func (s *Subscription) AwaitTransactionAccepted(id []byte) (*broadcast.TransactionAccepted, error) {
+	var transaction *broadcast.TransactionAccepted
+	err := s.await(fmt.Sprintf("accepted transaction 0x%s", hex.EncodeToString(id)), func() bool {
+		for _, tx := range s.transactions {
+			if bytes.Equal(tx.Transaction.GetId(), id) {
+				transaction = tx
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return transaction, err
+}
+
+// AwaitTransactionFailed returns the broadcast of the transaction with id failing
+func (s *Subscription) AwaitTransactionFailed(id []byte) (*broadcast.TransactionFailed, error) {
+	var transaction *broadcast.TransactionFailed
+	err := s.await(fmt.Sprintf("failed transaction 0x%s", hex.EncodeToString(id)), func() bool {
+		for _, tx := range s.failed {
+			if bytes.Equal(tx.Id, id) {
+				transaction = tx
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return transaction, err
+}
+
+// AwaitEvent returns the first event named name broadcast by the contract at source. A nil source
+// matches events from any contract.
+func (s *Subscription) AwaitEvent(name string, source []byte) (*broadcast.EventParcel, error) {
+	var event *broadcast.EventParcel
+	err := s.await(fmt.Sprintf("event %s", name), func() bool {
+		for _, e := range s.events {
+			if e.Event.GetName() == name && (source == nil || bytes.Equal(e.Event.GetSource(), source)) {
+				event = e
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return event, err
+}
+
+// await waits until done, called with the lock held, returns true
+func (s *Subscription) await(description string, done func() bool) error {
+	timer := time.NewTimer(s.Timeout)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		if done() {
+			s.mu.Unlock()
+			return nil
+		}
+
+		if len(s.errs) > 0 {
+			err := s.errs[0]
+			s.mu.Unlock()
+			return fmt.Errorf("awaiting %s: %w", description, err)
+		}
+
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return fmt.Errorf("awaiting %s: timed out after %s", description, s.Timeout)
+		case <-s.ctx.Done():
+			return fmt.Errorf("awaiting %s: %w", description, s.ctx.Err())
+		}
+	}
+}
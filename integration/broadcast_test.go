@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/broadcast"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func deliverMessage(t *testing.T, s *Subscription, topic string, message proto.Message) {
+	data, err := proto.Marshal(message)
+	require.NoError(t, err)
+	s.deliver(topic, data)
+}
+
+func TestSubscription(t *testing.T) {
+	s := newSubscription(context.Background())
+	defer s.Close()
+	s.Timeout = 5 * time.Second
+
+	var accepted []uint64
+	s.OnBlockAccepted(func(b *broadcast.BlockAccepted) {
+		accepted = append(accepted, b.Block.Header.Height)
+	})
+
+	block := func(height uint64) *broadcast.BlockAccepted {
+		return &broadcast.BlockAccepted{Block: &protocol.Block{Header: &protocol.BlockHeader{Height: height}}, Head: true}
+	}
+
+	t.Logf("Awaiting a block broadcast before the call")
+	deliverMessage(t, s, BlockAcceptedTopic, block(1))
+	b, err := s.AwaitBlock(1)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, b.Block.Header.Height)
+
+	t.Logf("Awaiting a block broadcast after the call")
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		deliverMessage(t, s, BlockAcceptedTopic, block(2))
+		deliverMessage(t, s, BlockAcceptedTopic, block(3))
+	}()
+
+	b, err = s.AwaitBlock(3)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, b.Block.Header.Height)
+	require.Equal(t, []uint64{1, 2, 3}, accepted)
+	require.Len(t, s.Blocks(), 3)
+
+	t.Logf("Awaiting transactions by id")
+	deliverMessage(t, s, TransactionAcceptedTopic, &broadcast.TransactionAccepted{Transaction: &protocol.Transaction{Id: []byte{1}}, Height: 3})
+	deliverMessage(t, s, TransactionFailedTopic, &broadcast.TransactionFailed{Id: []byte{2}})
+
+	tx, err := s.AwaitTransactionAccepted([]byte{1})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, tx.Height)
+
+	_, err = s.AwaitTransactionFailed([]byte{2})
+	require.NoError(t, err)
+
+	t.Logf("Collecting contract events")
+	source := []byte{9}
+	deliverMessage(t, s, EventTopicPrefix+"source.token.mint_event", &broadcast.EventParcel{Height: 3, Event: &protocol.EventData{Name: TokenMintEvent, Source: source}})
+	deliverMessage(t, s, EventTopicPrefix+"source.token.burn_event", &broadcast.EventParcel{Height: 3, Event: &protocol.EventData{Name: TokenBurnEvent, Source: source}})
+
+	event, err := s.AwaitEvent(TokenBurnEvent, source)
+	require.NoError(t, err)
+	require.Equal(t, TokenBurnEvent, event.Event.Name)
+	require.Len(t, s.Events(), 2)
+
+	t.Logf("Keeping the latest fork heads and irreversible blocks")
+	deliverMessage(t, s, ForkHeadsTopic, &broadcast.ForkHeads{Heads: []*koinos.BlockTopology{{Height: 3}}})
+	deliverMessage(t, s, BlockIrreversibleTopic, &broadcast.BlockIrreversible{Topology: &koinos.BlockTopology{Height: 2}})
+	require.EqualValues(t, 3, s.ForkHeads().Heads[0].Height)
+
+	_, err = s.AwaitIrreversible(2)
+	require.NoError(t, err)
+
+	t.Logf("Timing out when nothing is broadcast")
+	s.Timeout = 50 * time.Millisecond
+	_, err = s.AwaitTransactionAccepted([]byte{3})
+	require.ErrorContains(t, err, "accepted transaction 0x03: timed out")
+
+	t.Logf("Reporting broadcasts that cannot be decoded")
+	s.deliver(BlockAcceptedTopic, []byte{0xff})
+	require.Error(t, s.Err())
+	_, err = s.AwaitBlock(10)
+	require.ErrorContains(t, err, "decoding koinos.block.accept")
+
+	t.Logf("Ending the subscription")
+	s = newSubscription(context.Background())
+	s.Close()
+	_, err = s.AwaitBlock(1)
+	require.ErrorIs(t, err, context.Canceled)
+}
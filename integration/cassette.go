@@ -0,0 +1,325 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CassetteEntry is a single recorded rpc call
+type CassetteEntry struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+
+	// ChainError is true when the error was reported by the node, with the fields below
+	ChainError bool     `json:"chain_error,omitempty"`
+	Code       int32    `json:"code,omitempty"`
+	Logs       []string `json:"logs,omitempty"`
+
+	// OperationIndex is recorded with chain errors, nil when it was not reported
+	OperationIndex *int `json:"operation_index,omitempty"`
+}
+
+// RecordingClient wraps a Client, writing every call to a JSONL cassette
+type RecordingClient struct {
+	client Client
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+// NewRecordingClient creates a RecordingClient writing to the cassette at path
+func NewRecordingClient(client Client, path string) (*RecordingClient, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordingClient{client: client, file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+// Call forwards the call to the wrapped client and records the result. The error of the call is
+// returned even when the call cannot be recorded.
+func (r *RecordingClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	callErr := r.client.Call(ctx, method, params, returnType)
+
+	if err := r.record(method, params, returnType, callErr); err != nil {
+		if callErr != nil {
+			return fmt.Errorf("%w (recording %s: %v)", callErr, method, err)
+		}
+
+		return fmt.Errorf("recording %s: %w", method, err)
+	}
+
+	return callErr
+}
+
+// record writes the call to the cassette
+func (r *RecordingClient) record(method string, params proto.Message, returnType proto.Message, callErr error) error {
+	request, err := protojson.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	entry := CassetteEntry{Method: method, Request: request}
+
+	if callErr != nil {
+		entry.Error = callErr.Error()
+
+		if chainErr, ok := AsChainError(callErr); ok {
+			entry.ChainError = true
+			entry.Code = int32(chainErr.Code)
+			entry.Logs = chainErr.Logs
+			if chainErr.OperationIndex >= 0 {
+				index := chainErr.OperationIndex
+				entry.OperationIndex = &index
+			}
+		}
+	} else {
+		entry.Response, err = protojson.Marshal(returnType)
+		if err != nil {
+			return err
+		}
+	}
+
+	line, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err = r.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return r.writer.Flush()
+}
+
+// Close closes the cassette file
+func (r *RecordingClient) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.writer.Flush(); err != nil {
+		return err
+	}
+
+	return r.file.Close()
+}
+
+// RequestNormalizer clears non-deterministic parts of a request before recorded and
+// replayed requests are compared
+type RequestNormalizer func(method string, request proto.Message)
+
+// IgnoreFields returns a RequestNormalizer that clears the given fields on requests to method.
+// Fields are dotted proto field names, e.g. "block.header.timestamp". Repeated fields along the
+// path are traversed element by element. An empty method applies to every request.
+func IgnoreFields(method string, paths ...string) RequestNormalizer {
+	return func(m string, request proto.Message) {
+		if method != "" && method != m {
+			return
+		}
+
+		for _, path := range paths {
+			clearPath(request.ProtoReflect(), strings.Split(path, "."))
+		}
+	}
+}
+
+// IgnoreRequest returns a RequestNormalizer that ignores the entire request to method
+func IgnoreRequest(method string) RequestNormalizer {
+	return func(m string, request proto.Message) {
+		if method == m {
+			proto.Reset(request)
+		}
+	}
+}
+
+// BlockNormalizers ignores the fields CreateBlock derives from the wall clock and signing keys
+var BlockNormalizers = []RequestNormalizer{
+	IgnoreFields(SubmitBlockCall,
+		"block.id",
+		"block.signature",
+		"block.header.previous",
+		"block.header.timestamp",
+		"block.header.previous_state_merkle_root",
+		"block.header.transaction_merkle_root",
+	),
+}
+
+// TransactionNormalizers ignores the fields of transactions that change with generated keys,
+// and the accounts whose nonce and rc are requested
+var TransactionNormalizers = []RequestNormalizer{
+	IgnoreFields(GetAccountNonceCall, "account"),
+	IgnoreFields(GetPendingNonceCall, "payee"),
+	IgnoreFields(GetAccountRcCall, "account"),
+	IgnoreFields(SubmitTransactionCall,
+		"transaction.id",
+		"transaction.signatures",
+		"transaction.header",
+	),
+	IgnoreFields(SubmitBlockCall,
+		"block.transactions.id",
+		"block.transactions.signatures",
+		"block.transactions.header",
+	),
+}
+
+func clearPath(message protoreflect.Message, path []string) {
+	if len(path) == 0 {
+		return
+	}
+
+	field := message.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if field == nil {
+		return
+	}
+
+	if len(path) == 1 {
+		message.Clear(field)
+		return
+	}
+
+	if field.Message() == nil || !message.Has(field) {
+		return
+	}
+
+	if field.IsList() {
+		list := message.Mutable(field).List()
+		for i := 0; i < list.Len(); i++ {
+			clearPath(list.Get(i).Message(), path[1:])
+		}
+		return
+	}
+
+	clearPath(message.Mutable(field).Message(), path[1:])
+}
+
+// ReplayClient serves the calls recorded in a cassette, in order
+type ReplayClient struct {
+	mu          sync.Mutex
+	entries     []*CassetteEntry
+	next        int
+	normalizers []RequestNormalizer
+}
+
+// NewReplayClient creates a ReplayClient from the cassette at path
+func NewReplayClient(path string, normalizers ...RequestNormalizer) (*ReplayClient, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries, err := ReadCassette(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayClient{entries: entries, normalizers: normalizers}, nil
+}
+
+// ReadCassette reads the entries of a JSONL cassette
+func ReadCassette(r io.Reader) ([]*CassetteEntry, error) {
+	entries := make([]*CassetteEntry, 0)
+	decoder := json.NewDecoder(r)
+
+	for {
+		entry := &CassetteEntry{}
+		err := decoder.Decode(entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Call returns the next recorded response, failing if the request does not match the recording
+func (r *ReplayClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.next >= len(r.entries) {
+		return fmt.Errorf("cassette exhausted after %d calls, unexpected call to %s", len(r.entries), method)
+	}
+
+	index := r.next
+	entry := r.entries[index]
+	r.next++
+
+	if entry.Method != method {
+		return fmt.Errorf("cassette entry %d: expected call to %s, was %s", index, entry.Method, method)
+	}
+
+	recorded := params.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(entry.Request, recorded); err != nil {
+		return fmt.Errorf("cassette entry %d: %w", index, err)
+	}
+
+	actual := proto.Clone(params)
+	for _, normalize := range r.normalizers {
+		normalize(method, recorded)
+		normalize(method, actual)
+	}
+
+	if !proto.Equal(recorded, actual) {
+		expected, _ := protojson.Marshal(recorded)
+		was, _ := protojson.Marshal(actual)
+		return fmt.Errorf("cassette entry %d: request to %s does not match recording, expected %s, was %s", index, method, expected, was)
+	}
+
+	if len(entry.Error) > 0 {
+		return entry.err()
+	}
+
+	proto.Reset(returnType)
+	return protojson.Unmarshal(entry.Response, returnType)
+}
+
+// err returns the recorded error, a *ChainError when it was reported by the node
+func (e *CassetteEntry) err() error {
+	if !e.ChainError {
+		return errors.New(e.Error)
+	}
+
+	chainErr := &ChainError{
+		Code:           chain.ErrorCode(e.Code),
+		Message:        e.Error,
+		Logs:           e.Logs,
+		OperationIndex: -1,
+	}
+	if e.OperationIndex != nil {
+		chainErr.OperationIndex = *e.OperationIndex
+	}
+
+	return chainErr
+}
+
+// Remaining returns the number of recorded calls that have not been replayed
+func (r *ReplayClient) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.entries) - r.next
+}
@@ -0,0 +1,106 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"path/filepath"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRecordReplay(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	genesisKey, err := integration.GetKey(integration.Genesis)
+	require.NoError(t, err)
+
+	recorder, err := integration.NewRecordingClient(fake.NewClient(), cassette)
+	require.NoError(t, err)
+
+	tx, err := integration.CreateTransaction(recorder, []*protocol.Operation{}, genesisKey)
+	require.NoError(t, err)
+
+	recorded, err := integration.CreateBlock(recorder, []*protocol.Transaction{tx})
+	require.NoError(t, err)
+
+	_, err = integration.CreateBlock(recorder, []*protocol.Transaction{tx})
+	require.Error(t, err)
+
+	require.NoError(t, recorder.Close())
+
+	replay, err := integration.NewReplayClient(cassette, integration.BlockNormalizers...)
+	require.NoError(t, err)
+
+	tx, err = integration.CreateTransaction(replay, []*protocol.Operation{}, genesisKey)
+	require.NoError(t, err)
+
+	replayed, err := integration.CreateBlock(replay, []*protocol.Transaction{tx})
+	require.NoError(t, err)
+	require.EqualValues(t, recorded.Id, replayed.Id)
+
+	_, err = integration.CreateBlock(replay, []*protocol.Transaction{tx})
+	require.True(t, integration.IsInvalidNonce(err), "Expected the recorded nonce error, was %v", err)
+
+	chainErr, ok := integration.AsChainError(err)
+	require.True(t, ok)
+	require.Equal(t, -1, chainErr.OperationIndex)
+
+	require.Zero(t, replay.Remaining())
+
+	t.Logf("Replaying a different request fails")
+	replay, err = integration.NewReplayClient(cassette)
+	require.NoError(t, err)
+
+	_, err = integration.GetHeadInfo(replay)
+	require.Error(t, err)
+
+	t.Logf("Replaying nonce and rc requests of other accounts with the transaction normalizers")
+	cassette = filepath.Join(t.TempDir(), "accounts.jsonl")
+	recorder, err = integration.NewRecordingClient(fake.NewClient(), cassette)
+	require.NoError(t, err)
+
+	_, err = integration.GetAccountNonce(recorder, []byte{1})
+	require.NoError(t, err)
+	_, err = integration.GetAccountRc(recorder, []byte{1})
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	replay, err = integration.NewReplayClient(cassette, integration.TransactionNormalizers...)
+	require.NoError(t, err)
+
+	_, err = integration.GetAccountNonce(replay, []byte{2})
+	require.NoError(t, err)
+	_, err = integration.GetAccountRc(replay, []byte{2})
+	require.NoError(t, err)
+
+	t.Logf("Replaying errors not reported by the node as plain errors")
+	cassette = filepath.Join(t.TempDir(), "transport.jsonl")
+	recorder, err = integration.NewRecordingClient(failingClient{errors.New("connection refused")}, cassette)
+	require.NoError(t, err)
+
+	_, err = integration.GetHeadInfo(recorder)
+	require.EqualError(t, err, "connection refused")
+	require.NoError(t, recorder.Close())
+
+	replay, err = integration.NewReplayClient(cassette)
+	require.NoError(t, err)
+
+	_, err = integration.GetHeadInfo(replay)
+	require.EqualError(t, err, "connection refused")
+	_, ok = integration.AsChainError(err)
+	require.False(t, ok, "Expected a plain error, was %T", err)
+}
+
+// failingClient fails every call with err
+type failingClient struct {
+	err error
+}
+
+func (c failingClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	return c.err
+}
@@ -1,6 +1,7 @@
 package claim
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"koinos-integration-tests/integration"
@@ -12,23 +13,30 @@ import (
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
 	util "github.com/koinos/koinos-util-golang/v2"
 	"github.com/mr-tron/base58"
-	"google.golang.org/protobuf/proto"
 )
 
-const (
-	claimEntry      uint32 = 0xdd1b3c31
-	getInfoEntry    uint32 = 0xbd7f6850
-	checkClaimEntry uint32 = 0x2ac66b4c
-)
+//go:generate go run ../../cmd/koinos-abigen -abi claim.abi.json -out claim_abi.go
 
 // Claim is a wrapper around the claim contract
 type Claim struct {
-	key    *util.KoinosKey
-	client integration.Client
+	key      *util.KoinosKey
+	client   integration.Client
+	contract *ClaimContract
+}
+
+// WithContext returns a copy of the claim contract whose calls are made within ctx
+func (c *Claim) WithContext(ctx context.Context) *Claim {
+	return c.withClient(integration.WithContext(c.client, ctx))
+}
+
+func (c *Claim) withClient(client integration.Client) *Claim {
+	return &Claim{key: c.key, client: client, contract: NewClaimContract(client, c.contract.Address)}
 }
 
 // SubmitClaim to the claim contract
 func (c *Claim) SubmitClaim(t *testing.T, ethAddress []byte, privateKey []byte, koinAddress *util.KoinosKey, payer *util.KoinosKey) (*protocol.BlockReceipt, error) {
+	c = c.withClient(integration.ForTest(t, c.client))
+
 	pk, _ := btcec.PrivKeyFromBytes(btcec.S256(), privateKey)
 
 	messageStr := "claim tkoins 0x" + hex.EncodeToString(ethAddress) + ":" + base58.Encode(koinAddress.AddressBytes())
@@ -47,31 +55,13 @@ func (c *Claim) SubmitClaim(t *testing.T, ethAddress []byte, privateKey []byte,
 		Signature:   sig,
 	}
 
-	args, err := proto.Marshal(claimArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	op := &protocol.Operation{
-		Op: &protocol.Operation_CallContract{
-			CallContract: &protocol.CallContractOperation{
-				ContractId: c.key.AddressBytes(),
-				EntryPoint: claimEntry,
-				Args:       args,
-			},
-		},
-	}
-
-	transaction, err := integration.CreateTransaction(c.client, []*protocol.Operation{op}, payer)
-	if err != nil {
-		return nil, err
-	}
-
-	return integration.CreateBlock(c.client, []*protocol.Transaction{transaction})
+	return c.contract.Claim(claimArgs, payer)
 }
 
 // SubmitClaimWithDelegation to the claim contract
 func (c *Claim) SubmitClaimWithDelegation(t *testing.T, ethAddress []byte, privateKey []byte, koinAddress *util.KoinosKey, payer *util.KoinosKey) (*protocol.BlockReceipt, error) {
+	c = c.withClient(integration.ForTest(t, c.client))
+
 	pk, _ := btcec.PrivKeyFromBytes(btcec.S256(), privateKey)
 
 	messageStr := "claim tkoins 0x" + hex.EncodeToString(ethAddress) + ":" + base58.Encode(koinAddress.AddressBytes())
@@ -90,63 +80,28 @@ func (c *Claim) SubmitClaimWithDelegation(t *testing.T, ethAddress []byte, priva
 		Signature:   sig,
 	}
 
-	args, err := proto.Marshal(claimArgs)
+	op, err := c.contract.ClaimOperation(claimArgs)
 	if err != nil {
 		return nil, err
 	}
 
-	op := &protocol.Operation{
-		Op: &protocol.Operation_CallContract{
-			CallContract: &protocol.CallContractOperation{
-				ContractId: c.key.AddressBytes(),
-				EntryPoint: claimEntry,
-				Args:       args,
-			},
-		},
-	}
-
-	transaction, err := integration.CreateTransaction(
+	transaction, err := integration.CreateTransactionWith(
 		c.client,
 		[]*protocol.Operation{op},
-		koinAddress,
-		func(t *protocol.Transaction) error {
-			t.Header.Payee = koinAddress.AddressBytes()
-			t.Header.Payer = payer.AddressBytes()
-
-			rcLimit, err := integration.GetAccountRc(c.client, t.Header.Payer)
-			if err != nil {
-				return err
-			}
-
-			t.Header.RcLimit = rcLimit
-
-			return nil
-		},
+		integration.WithSigner(koinAddress),
+		integration.WithPayer(payer.AddressBytes()),
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return integration.CreateBlock(c.client, []*protocol.Transaction{transaction})
+	return integration.CreateBlockWith(c.client, []*protocol.Transaction{transaction})
 }
 
 // GetInfo from the claim contract
 func (c *Claim) GetInfo() (*claim.ClaimInfo, error) {
-	getInfoArgs := &claim.GetInfoArguments{}
-
-	args, err := proto.Marshal(getInfoArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := integration.ReadContract(c.client, args, c.key.AddressBytes(), getInfoEntry)
-	if err != nil {
-		return nil, err
-	}
-
-	info := &claim.GetInfoResult{}
-	err = proto.Unmarshal(resp.GetResult(), info)
+	info, err := c.contract.GetInfo(&claim.GetInfoArguments{})
 	if err != nil {
 		return nil, err
 	}
@@ -156,20 +111,7 @@ func (c *Claim) GetInfo() (*claim.ClaimInfo, error) {
 
 // CheckClaim checks the status of a claim
 func (c *Claim) CheckClaim(ethAddress []byte) (*claim.ClaimStatus, error) {
-	checkClaimArgs := &claim.CheckClaimArguments{EthAddress: ethAddress}
-
-	args, err := proto.Marshal(checkClaimArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := integration.ReadContract(c.client, args, c.key.AddressBytes(), checkClaimEntry)
-	if err != nil {
-		return nil, err
-	}
-
-	check := &claim.CheckClaimResult{}
-	err = proto.Unmarshal(resp.GetResult(), check)
+	check, err := c.contract.CheckClaim(&claim.CheckClaimArguments{EthAddress: ethAddress})
 	if err != nil {
 		return nil, err
 	}
@@ -184,5 +126,5 @@ func (c *Claim) CheckClaim(ethAddress []byte) (*claim.ClaimStatus, error) {
 // NewClaim creates a new Claim wrapper
 func NewClaim(client integration.Client) *Claim {
 	claimKey, _ := integration.GetKey(integration.Claim)
-	return &Claim{key: claimKey, client: client}
+	return &Claim{key: claimKey, client: client, contract: NewClaimContract(client, claimKey.AddressBytes())}
 }
@@ -0,0 +1,327 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+)
+
+const (
+	defaultClusterTimeout      = 2 * time.Minute
+	defaultClusterPollInterval = time.Second
+)
+
+// Node is a named node of a Cluster
+type Node struct {
+	Name   string
+	Client Client
+}
+
+// Cluster is a set of named nodes that are expected to share a chain
+type Cluster struct {
+	// Timeout bounds how long the Await functions wait for the nodes, within the test deadline
+	Timeout time.Duration
+	// PollInterval is the delay between queries of the nodes while waiting
+	PollInterval time.Duration
+
+	nodes []*Node
+}
+
+// NewCluster creates a Cluster of the nodes. Node names must be unique, the test fails otherwise.
+func NewCluster(t *testing.T, nodes ...*Node) *Cluster {
+	t.Helper()
+
+	names := make(map[string]struct{})
+	for _, node := range nodes {
+		if _, ok := names[node.Name]; ok {
+			t.Fatalf("Duplicate cluster node %s", node.Name)
+		}
+
+		names[node.Name] = struct{}{}
+	}
+
+	return &Cluster{
+		Timeout:      defaultClusterTimeout,
+		PollInterval: defaultClusterPollInterval,
+		nodes:        nodes,
+	}
+}
+
+// NewJSONRPCCluster creates a Cluster of JSON-RPC nodes from a map of node names to urls
+func NewJSONRPCCluster(t *testing.T, urls map[string]string) *Cluster {
+	t.Helper()
+
+	names := make([]string, 0, len(urls))
+	for name := range urls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	nodes := make([]*Node, len(names))
+	for i, name := range names {
+		nodes[i] = &Node{Name: name, Client: NewJSONRPCClient(urls[name])}
+	}
+
+	return NewCluster(t, nodes...)
+}
+
+// Nodes returns the nodes of the cluster in the order they were added
+func (c *Cluster) Nodes() []*Node {
+	return c.nodes
+}
+
+// Node returns the client of the named node, failing the test if the cluster has no such node
+func (c *Cluster) Node(t *testing.T, name string) Client {
+	t.Helper()
+
+	for _, node := range c.nodes {
+		if node.Name == name {
+			return node.Client
+		}
+	}
+
+	t.Fatalf("Unknown cluster node %s", name)
+	return nil
+}
+
+// AwaitChain blocks until every node's chain rpc is responding
+func (c *Cluster) AwaitChain(t *testing.T) {
+	for _, node := range c.nodes {
+		t.Logf("Waiting for %s...", node.Name)
+		AwaitChain(t, node.Client)
+	}
+}
+
+// NodeHead is the head of a node, or the error querying it
+type NodeHead struct {
+	Node      string
+	Topology  *koinos.BlockTopology
+	StateRoot []byte
+	Err       error
+}
+
+// HeadDivergence describes the heads of every node in a cluster
+type HeadDivergence struct {
+	Heads []*NodeHead
+}
+
+// HeadDivergence queries the head of every node. Use Converged on the result to check agreement.
+func (c *Cluster) HeadDivergence() *HeadDivergence {
+	report := &HeadDivergence{}
+
+	for _, node := range c.nodes {
+		head := &NodeHead{Node: node.Name}
+
+		headInfo, err := GetHeadInfo(node.Client)
+		if err != nil {
+			head.Err = err
+		} else {
+			head.Topology = headInfo.HeadTopology
+			head.StateRoot = headInfo.HeadStateMerkleRoot
+		}
+
+		report.Heads = append(report.Heads, head)
+	}
+
+	return report
+}
+
+// Converged returns true if every node responded with the same head block
+func (d *HeadDivergence) Converged() bool {
+	if len(d.Heads) == 0 {
+		return true
+	}
+
+	for _, head := range d.Heads {
+		if head.Err != nil || !bytes.Equal(head.Topology.GetId(), d.Heads[0].Topology.GetId()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MinHeight returns the lowest head height of the nodes that responded
+func (d *HeadDivergence) MinHeight() uint64 {
+	var height uint64
+	first := true
+
+	for _, head := range d.Heads {
+		if head.Err != nil {
+			continue
+		}
+
+		if first || head.Topology.GetHeight() < height {
+			height = head.Topology.GetHeight()
+			first = false
+		}
+	}
+
+	return height
+}
+
+// MaxHeight returns the highest head height of the nodes that responded
+func (d *HeadDivergence) MaxHeight() uint64 {
+	var height uint64
+
+	for _, head := range d.Heads {
+		if head.Err == nil && head.Topology.GetHeight() > height {
+			height = head.Topology.GetHeight()
+		}
+	}
+
+	return height
+}
+
+// String returns a line per node with its head, grouping nodes that agree
+func (d *HeadDivergence) String() string {
+	groups := make(map[string]int)
+	var lines []string
+
+	for _, head := range d.Heads {
+		if head.Err != nil {
+			lines = append(lines, fmt.Sprintf("%s: error: %s", head.Node, head.Err))
+			continue
+		}
+
+		id := hex.EncodeToString(head.Topology.GetId())
+		if _, ok := groups[id]; !ok {
+			groups[id] = len(groups)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: fork %d height %d id 0x%s previous 0x%s",
+			head.Node,
+			groups[id],
+			head.Topology.GetHeight(),
+			id,
+			hex.EncodeToString(head.Topology.GetPrevious())))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// AwaitAllAtHeight blocks until every node's head is at least height
+func (c *Cluster) AwaitAllAtHeight(t *testing.T, height uint64) *HeadDivergence {
+	return c.await(t, fmt.Sprintf("all nodes at height %d", height), func(d *HeadDivergence) bool {
+		for _, head := range d.Heads {
+			if head.Err != nil || head.Topology.GetHeight() < height {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// AwaitConvergence blocks until every node has the same head block, returning its topology
+func (c *Cluster) AwaitConvergence(t *testing.T) *koinos.BlockTopology {
+	report := c.await(t, "head convergence", (*HeadDivergence).Converged)
+	if len(report.Heads) == 0 {
+		return nil
+	}
+
+	return report.Heads[0].Topology
+}
+
+func (c *Cluster) await(t *testing.T, description string, done func(*HeadDivergence) bool) *HeadDivergence {
+	t.Helper()
+
+	var report *HeadDivergence
+	WaitFor(TestContext(t), t, description, Condition{
+		Check: func() (bool, string, error) {
+			report = c.HeadDivergence()
+			return done(report), "\n" + report.String(), nil
+		},
+	}, c.waitOptions()...)
+
+	return report
+}
+
+// waitOptions polls the nodes every PollInterval for up to Timeout
+func (c *Cluster) waitOptions() []WaitOption {
+	return []WaitOption{WithWaitTimeout(c.Timeout), WithBackoff(c.PollInterval, c.PollInterval)}
+}
+
+// AccountNonces returns the account nonce of address on each node
+func (c *Cluster) AccountNonces(address []byte) (map[string]uint64, error) {
+	return c.nonces(address, GetAccountNonce)
+}
+
+// PendingNonces returns the pending nonce of address in each node's mempool
+func (c *Cluster) PendingNonces(address []byte) (map[string]uint64, error) {
+	return c.nonces(address, GetPendingNonce)
+}
+
+func (c *Cluster) nonces(address []byte, get func(Client, []byte) (uint64, error)) (map[string]uint64, error) {
+	nonces := make(map[string]uint64)
+
+	for _, node := range c.nodes {
+		nonce, err := get(node.Client, address)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Name, err)
+		}
+
+		nonces[node.Name] = nonce
+	}
+
+	return nonces, nil
+}
+
+// PendingTransactions returns up to limit pending transactions in each node's mempool
+func (c *Cluster) PendingTransactions(limit uint64) (map[string][]*protocol.Transaction, error) {
+	pending := make(map[string][]*protocol.Transaction)
+
+	for _, node := range c.nodes {
+		pendingTrxs, err := GetPendingTransactions(node.Client, limit)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Name, err)
+		}
+
+		transactions := make([]*protocol.Transaction, 0, len(pendingTrxs))
+		for _, pendingTrx := range pendingTrxs {
+			transactions = append(transactions, pendingTrx.Transaction)
+		}
+
+		pending[node.Name] = transactions
+	}
+
+	return pending, nil
+}
+
+// AwaitAccountNonce blocks until address has the account nonce on every node
+func (c *Cluster) AwaitAccountNonce(t *testing.T, address []byte, nonce uint64) {
+	c.awaitNonces(t, "account", address, nonce, c.AccountNonces)
+}
+
+// AwaitPendingNonce blocks until address has the pending nonce in every node's mempool
+func (c *Cluster) AwaitPendingNonce(t *testing.T, address []byte, nonce uint64) {
+	c.awaitNonces(t, "pending", address, nonce, c.PendingNonces)
+}
+
+func (c *Cluster) awaitNonces(t *testing.T, kind string, address []byte, nonce uint64, get func([]byte) (map[string]uint64, error)) {
+	t.Helper()
+
+	WaitFor(TestContext(t), t, fmt.Sprintf("%s nonce %d", kind, nonce), Condition{
+		Check: func() (bool, string, error) {
+			nonces, err := get(address)
+			if err != nil {
+				return false, "", err
+			}
+
+			for _, n := range nonces {
+				if n != nonce {
+					return false, fmt.Sprintf("%s nonces: %v", kind, nonces), nil
+				}
+			}
+
+			return true, fmt.Sprintf("%s nonces: %v", kind, nonces), nil
+		},
+	}, c.waitOptions()...)
+}
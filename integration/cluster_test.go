@@ -0,0 +1,125 @@
+package integration_test
+
+import (
+	"context"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	chainrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// relayBlocks applies the blocks of from up to height to to, as p2p would
+func relayBlocks(t *testing.T, from *fake.Client, to *fake.Client, height uint64) {
+	for _, block := range from.Blocks() {
+		if block.Header.Height > height {
+			break
+		}
+
+		headInfo, err := integration.GetHeadInfo(to)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if block.Header.Height <= headInfo.HeadTopology.Height {
+			continue
+		}
+
+		err = to.Call(context.Background(), integration.SubmitBlockCall, &chainrpc.SubmitBlockRequest{Block: block}, &chainrpc.SubmitBlockResponse{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+}
+
+func TestCluster(t *testing.T) {
+	producer := fake.NewClient()
+	follower := fake.NewClient()
+
+	cluster := integration.NewCluster(
+		t,
+		&integration.Node{Name: "producer", Client: producer},
+		&integration.Node{Name: "follower", Client: follower},
+	)
+	cluster.Timeout = time.Second
+	cluster.PollInterval = time.Millisecond
+
+	require.True(t, cluster.HeadDivergence().Converged())
+
+	_, err := integration.CreateBlocks(producer, 3)
+	require.NoError(t, err)
+
+	t.Logf("Reporting divergent heads")
+	report := cluster.HeadDivergence()
+	require.False(t, report.Converged())
+	require.EqualValues(t, 0, report.MinHeight())
+	require.EqualValues(t, 3, report.MaxHeight())
+	require.Contains(t, report.String(), "producer: fork 0 height 3")
+	require.Contains(t, report.String(), "follower: fork 1 height 0")
+
+	t.Logf("Awaiting the follower")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		relayBlocks(t, producer, follower, 2)
+		time.Sleep(10 * time.Millisecond)
+		relayBlocks(t, producer, follower, 3)
+	}()
+
+	report = cluster.AwaitAllAtHeight(t, 2)
+	require.GreaterOrEqual(t, report.MinHeight(), uint64(2))
+
+	head := cluster.AwaitConvergence(t)
+	require.EqualValues(t, 3, head.Height)
+	require.Equal(t, producer.Blocks()[2].Id, head.Id)
+}
+
+func TestClusterNonces(t *testing.T) {
+	producer := fake.NewClient()
+	api := fake.NewClient()
+
+	cluster := integration.NewCluster(
+		t,
+		&integration.Node{Name: "producer", Client: producer},
+		&integration.Node{Name: "api", Client: api},
+	)
+	cluster.Timeout = time.Second
+	cluster.PollInterval = time.Millisecond
+
+	key, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	tx, err := integration.CreateTransaction(api, []*protocol.Operation{}, key)
+	require.NoError(t, err)
+
+	_, err = integration.SubmitTransaction(api, tx)
+	require.NoError(t, err)
+
+	pendingNonces, err := cluster.PendingNonces(key.AddressBytes())
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"producer": 0, "api": 1}, pendingNonces)
+
+	pending, err := cluster.PendingTransactions(10)
+	require.NoError(t, err)
+	require.Len(t, pending["producer"], 0)
+	require.Len(t, pending["api"], 1)
+
+	_, err = integration.SubmitTransaction(producer, tx)
+	require.NoError(t, err)
+	cluster.AwaitPendingNonce(t, key.AddressBytes(), 1)
+
+	_, err = integration.CreateBlock(producer, []*protocol.Transaction{tx})
+	require.NoError(t, err)
+	relayBlocks(t, producer, api, 1)
+
+	cluster.AwaitAccountNonce(t, key.AddressBytes(), 1)
+
+	accountNonces, err := cluster.AccountNonces(key.AddressBytes())
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"producer": 1, "api": 1}, accountNonces)
+}
@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// CallTimeoutEnv overrides DefaultTimeout, parsed with time.ParseDuration
+	CallTimeoutEnv = "KOINOS_CALL_TIMEOUT"
+	// BlockTimeoutEnv overrides DefaultBlockTimeout, parsed with time.ParseDuration
+	BlockTimeoutEnv = "KOINOS_BLOCK_TIMEOUT"
+
+	// testDeadlineGrace is how long before the test deadline calls are cancelled, leaving time to report the failure
+	testDeadlineGrace = 5 * time.Second
+)
+
+var (
+	// DefaultTimeout bounds each call made by the helpers, unless the client sets a timeout with WithTimeout
+	DefaultTimeout = durationFromEnv(CallTimeoutEnv, time.Second)
+	// DefaultBlockTimeout bounds block submission, which can take much longer than other calls when
+	// applying large contract uploads
+	DefaultBlockTimeout = durationFromEnv(BlockTimeoutEnv, 10*time.Second)
+)
+
+func durationFromEnv(name string, value time.Duration) time.Duration {
+	if env, ok := os.LookupEnv(name); ok {
+		if d, err := time.ParseDuration(env); err == nil {
+			return d
+		}
+	}
+
+	return value
+}
+
+// contextClient is a Client bound to a context and timeout used by the helpers
+type contextClient struct {
+	Client
+	ctx     context.Context
+	timeout time.Duration
+	t       *testing.T
+}
+
+// WithContext returns a client whose helper calls are made within ctx.
+// Cancelling ctx cancels any outstanding and future calls.
+func WithContext(client Client, ctx context.Context) Client {
+	c := bind(client)
+	c.ctx = ctx
+	c.t = nil
+	return c
+}
+
+// WithTimeout returns a client whose helper calls are each bounded by timeout, instead of
+// DefaultTimeout and DefaultBlockTimeout
+func WithTimeout(client Client, timeout time.Duration) Client {
+	c := bind(client)
+	c.timeout = timeout
+	return c
+}
+
+// ForTest returns a client whose helper calls are cancelled shortly before the test deadline
+// or when the test finishes
+func ForTest(t *testing.T, client Client) Client {
+	if c, ok := client.(*contextClient); ok && c.t == t {
+		return client
+	}
+
+	c := bind(client)
+	c.ctx = testContext(t, c.ctx)
+	c.t = t
+	return c
+}
+
+// TestContext returns a context cancelled shortly before the deadline of the test binary,
+// as set with go test -timeout, or when the test finishes
+func TestContext(t *testing.T) context.Context {
+	return testContext(t, context.Background())
+}
+
+func testContext(t *testing.T, parent context.Context) context.Context {
+	if deadline, ok := t.Deadline(); ok {
+		ctx, cancel := context.WithDeadline(parent, deadline.Add(-testDeadlineGrace))
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func bind(client Client) *contextClient {
+	if c, ok := client.(*contextClient); ok {
+		bound := *c
+		return &bound
+	}
+
+	return &contextClient{Client: client, ctx: context.Background()}
+}
+
+// Call makes the call within both ctx and the context the client is bound to, returning node
+// errors as *ChainError
+func (c *contextClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return decodeChainError(c.Client.Call(ctx, method, params, returnType))
+}
+
+// clientContext returns the context the client is bound to
+func clientContext(client Client) context.Context {
+	if c, ok := client.(*contextClient); ok {
+		return c.ctx
+	}
+
+	return context.Background()
+}
+
+// testDeadline returns the earlier of timeout from now and the test deadline
+func testDeadline(t *testing.T, timeout time.Duration) time.Time {
+	deadline := time.Now().Add(timeout)
+	if testDeadline, ok := t.Deadline(); ok && testDeadline.Add(-testDeadlineGrace).Before(deadline) {
+		return testDeadline.Add(-testDeadlineGrace)
+	}
+
+	return deadline
+}
+
+// callContext returns the context for a single call by a helper
+func callContext(client Client, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if c, ok := client.(*contextClient); ok {
+		if c.timeout > 0 {
+			timeout = c.timeout
+		}
+
+		return context.WithTimeout(c.ctx, timeout)
+	}
+
+	return context.WithTimeout(context.Background(), timeout)
+}
@@ -0,0 +1,72 @@
+package integration_test
+
+import (
+	"context"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// deadlineClient records the deadline of the last call
+type deadlineClient struct {
+	integration.Client
+	deadline time.Time
+}
+
+func (c *deadlineClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	c.deadline, _ = ctx.Deadline()
+	return c.Client.Call(ctx, method, params, returnType)
+}
+
+func TestCallTimeouts(t *testing.T) {
+	client := &deadlineClient{Client: fake.NewClient()}
+
+	t.Logf("Using the default timeout")
+	start := time.Now()
+	_, err := integration.GetHeadInfo(client)
+	require.NoError(t, err)
+	require.WithinDuration(t, start.Add(integration.DefaultTimeout), client.deadline, time.Second)
+
+	t.Logf("Overriding the timeout")
+	start = time.Now()
+	_, err = integration.GetHeadInfo(integration.WithTimeout(client, time.Hour))
+	require.NoError(t, err)
+	require.WithinDuration(t, start.Add(time.Hour), client.deadline, time.Second)
+
+	t.Logf("Bounding calls with a context")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start = time.Now()
+	bound := integration.WithTimeout(integration.WithContext(client, ctx), time.Hour)
+	_, err = integration.GetHeadInfo(bound)
+	require.NoError(t, err)
+	require.WithinDuration(t, start.Add(time.Minute), client.deadline, time.Second)
+
+	cancel()
+	_, err = integration.GetHeadInfo(bound)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = integration.GetHeadInfo(client)
+	require.NoError(t, err)
+}
+
+func TestForTest(t *testing.T) {
+	var bound integration.Client
+
+	t.Run("bound", func(t *testing.T) {
+		bound = integration.ForTest(t, fake.NewClient())
+		require.Same(t, bound, integration.ForTest(t, bound))
+
+		_, err := integration.CreateBlock(bound, nil)
+		require.NoError(t, err)
+	})
+
+	t.Logf("Calls fail once the test has finished")
+	_, err := integration.GetHeadInfo(bound)
+	require.ErrorIs(t, err, context.Canceled)
+}
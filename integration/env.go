@@ -0,0 +1,235 @@
+package integration
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Transport is the protocol a client uses to reach a node
+type Transport string
+
+// Transports of NewClientFromEnv
+const (
+	JSONRPC Transport = "jsonrpc"
+	AMQP    Transport = "amqp"
+
+	// Parity compares the responses of read-only calls over jsonrpc and amqp with a ComparingClient
+	Parity Transport = "parity"
+)
+
+// Environment of NewClientFromEnv and NodesFromEnv. Each is also a flag of the test binary, named
+// in lower case with dots, such as -koinos.transport, which takes precedence over the variable.
+const (
+	// TransportEnv is the transport of NewClientFromEnv, jsonrpc, amqp or parity
+	TransportEnv = "KOINOS_TRANSPORT"
+	// JSONRPCURLEnv overrides the url of the jsonrpc service built from the jsonrpc port
+	JSONRPCURLEnv = "KOINOS_JSONRPC_URL"
+	// AMQPURLEnv overrides the url of the amqp service built from the amqp port
+	AMQPURLEnv = "KOINOS_AMQP_URL"
+	// NodesEnv overrides the jsonrpc urls of named nodes, as a comma separated list of name=url
+	NodesEnv = "KOINOS_NODES"
+	// ConnectTimeoutEnv is how long to wait for an endpoint to accept connections, parsed with
+	// time.ParseDuration
+	ConnectTimeoutEnv = "KOINOS_CONNECT_TIMEOUT"
+	// RequireEndpointsEnv fails, instead of skips, a test whose endpoints are unreachable when set
+	RequireEndpointsEnv = "KOINOS_REQUIRE_ENDPOINTS"
+)
+
+// endpointFlags are the flags of the environment, registered only in test binaries
+var endpointFlags = make(map[string]*string)
+
+func init() {
+	name := filepath.Base(os.Args[0])
+	if !strings.HasSuffix(name, ".test") && !strings.HasSuffix(name, ".test.exe") {
+		return
+	}
+
+	for env, usage := range map[string]string{
+		TransportEnv:        "Transport to the node, jsonrpc, amqp or parity",
+		JSONRPCURLEnv:       "Url of the jsonrpc service",
+		AMQPURLEnv:          "Url of the amqp service",
+		NodesEnv:            "Jsonrpc urls of named nodes, such as producer=http://localhost:28080/",
+		ConnectTimeoutEnv:   "How long to wait for an endpoint to accept connections",
+		RequireEndpointsEnv: "Fail instead of skip tests whose endpoints are unreachable",
+	} {
+		endpointFlags[env] = flag.String(envFlagName(env), "", usage)
+	}
+}
+
+// envFlagName returns the flag of an environment variable, such as koinos.transport for KOINOS_TRANSPORT
+func envFlagName(env string) string {
+	name := strings.ToLower(strings.TrimPrefix(env, "KOINOS_"))
+	return "koinos." + strings.ReplaceAll(name, "_", "-")
+}
+
+// setting returns the value of the flag of the environment variable, or of the variable
+func setting(env string) string {
+	if f, ok := endpointFlags[env]; ok && len(*f) > 0 {
+		return *f
+	}
+
+	return os.Getenv(env)
+}
+
+// NewClientFromEnv returns a client of the node under test, bound to the test with ForTest. The
+// transport defaults to jsonrpc. If the endpoint does not accept connections within the connect
+// timeout, the test is skipped.
+func NewClientFromEnv(t *testing.T) Client {
+	return NewTransportClientFromEnv(t, JSONRPC)
+}
+
+// NewTransportClientFromEnv is NewClientFromEnv for suites whose default transport is not jsonrpc
+func NewTransportClientFromEnv(t *testing.T, fallback Transport) Client {
+	t.Helper()
+
+	transport := Transport(setting(TransportEnv))
+	if len(transport) == 0 {
+		transport = fallback
+	}
+
+	if transport == Parity {
+		return ForTest(t, NewComparingClient(newTransportClient(t, JSONRPC), newTransportClient(t, AMQP)))
+	}
+
+	return ForTest(t, newTransportClient(t, transport))
+}
+
+// newTransportClient returns a client of the node under test over transport, skipping the test if
+// it is unreachable
+func newTransportClient(t *testing.T, transport Transport) Client {
+	t.Helper()
+
+	var client Client
+	var endpoint string
+
+	switch transport {
+	case JSONRPC:
+		endpoint = urlSetting(JSONRPCURLEnv, JSONRPCURL(0))
+		client = NewJSONRPCClient(endpoint)
+	case AMQP:
+		endpoint = urlSetting(AMQPURLEnv, AMQPURL())
+		client = NewKoinosMQClient(endpoint)
+	default:
+		t.Fatalf("Unknown transport %q in %s, expected %s, %s or %s", transport, TransportEnv, JSONRPC, AMQP, Parity)
+	}
+
+	requireReachable(t, string(transport), endpoint)
+	return client
+}
+
+// AMQPURLFromEnv returns the url of the amqp service of the node under test, for suites that
+// publish to it directly. If the endpoint does not accept connections within the connect timeout,
+// the test is skipped.
+func AMQPURLFromEnv(t *testing.T) string {
+	t.Helper()
+
+	endpoint := urlSetting(AMQPURLEnv, AMQPURL())
+	requireReachable(t, string(AMQP), endpoint)
+
+	return endpoint
+}
+
+// NodesFromEnv returns a jsonrpc node of each name, bound to the test with ForTest. Node i defaults
+// to the jsonrpc port of node i, and the first node to the jsonrpc url. If a node does not accept
+// connections within the connect timeout, the test is skipped.
+func NodesFromEnv(t *testing.T, names ...string) []*Node {
+	t.Helper()
+
+	urls := make(map[string]string)
+	for _, entry := range strings.Split(setting(NodesEnv), ",") {
+		if name, u, ok := strings.Cut(strings.TrimSpace(entry), "="); ok {
+			urls[name] = u
+		}
+	}
+
+	nodes := make([]*Node, len(names))
+	for i, name := range names {
+		endpoint, ok := urls[name]
+		if !ok && i == 0 {
+			endpoint = urlSetting(JSONRPCURLEnv, JSONRPCURL(0))
+		} else if !ok {
+			endpoint = JSONRPCURL(i)
+		}
+
+		requireReachable(t, fmt.Sprintf("%s node %s", JSONRPC, name), endpoint)
+		nodes[i] = &Node{Name: name, Client: ForTest(t, NewJSONRPCClient(endpoint))}
+	}
+
+	return nodes
+}
+
+// urlSetting returns the setting of the environment variable, or fallback when unset
+func urlSetting(env string, fallback string) string {
+	if value := setting(env); len(value) > 0 {
+		return value
+	}
+
+	return fallback
+}
+
+// requireReachable skips the test, or fails it when endpoints are required, unless the endpoint
+// accepts connections within the connect timeout
+func requireReachable(t *testing.T, description string, endpoint string) {
+	t.Helper()
+
+	timeout := 10 * time.Second
+	if value := setting(ConnectTimeoutEnv); len(value) > 0 {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			t.Fatalf("Invalid %s %q: %s", ConnectTimeoutEnv, value, err)
+		}
+
+		timeout = d
+	}
+
+	err := awaitConnectable(endpoint, testDeadline(t, timeout))
+	if err == nil {
+		return
+	}
+
+	message := fmt.Sprintf("%s endpoint %s is unreachable: %s. Start the suite's cluster, or set %s, %s or %s.", description, endpoint, err, JSONRPCURLEnv, AMQPURLEnv, NodesEnv)
+	if len(setting(RequireEndpointsEnv)) > 0 {
+		t.Fatal(message)
+	}
+
+	t.Skip(message)
+}
+
+// awaitConnectable dials the host of the endpoint url until it accepts a connection or the deadline passes
+func awaitConnectable(endpoint string, deadline time.Time) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	host := u.Host
+	if len(u.Port()) == 0 {
+		port := map[string]string{"http": "80", "https": "443", "amqp": "5672", "amqps": "5671"}[u.Scheme]
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	for {
+		timeout := time.Until(deadline)
+		if timeout < 250*time.Millisecond {
+			timeout = 250 * time.Millisecond
+		}
+
+		conn, err := net.DialTimeout("tcp", host, timeout)
+		if err == nil {
+			return conn.Close()
+		}
+
+		if time.Now().Add(250 * time.Millisecond).After(deadline) {
+			return err
+		}
+
+		time.Sleep(250 * time.Millisecond)
+	}
+}
@@ -0,0 +1,173 @@
+package integration
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc"
+	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
+	jsonrpc "github.com/ybbus/jsonrpc/v3"
+)
+
+// ChainError is an error returned by a koinos node
+//
+// Reversions (positive codes) revert the transaction but still include it in a block.
+// Failures (negative codes) prevent the transaction or block from being applied.
+// When the node does not report an error code, Code is chain.ErrorCode_failure.
+//
+// Both transports read the code, logs and operation index from the error data of the node, and
+// the MQ transport also from its chain.ErrorDetails.
+type ChainError struct {
+	Code    chain.ErrorCode
+	Message string
+	Logs    []string
+
+	// OperationIndex is the index of the failing operation, or -1 when it was not reported
+	OperationIndex int
+}
+
+// Error returns the error message
+func (e *ChainError) Error() string {
+	return e.Message
+}
+
+// errorData is the error data attached to JSON-RPC errors
+type errorData struct {
+	Code           *int32   `json:"code"`
+	Logs           []string `json:"logs"`
+	OperationIndex *int     `json:"operation_index"`
+}
+
+func (d *errorData) apply(e *ChainError) {
+	if d.Code != nil {
+		e.Code = chain.ErrorCode(*d.Code)
+	}
+	if len(d.Logs) > 0 {
+		e.Logs = d.Logs
+	}
+	if d.OperationIndex != nil {
+		e.OperationIndex = *d.OperationIndex
+	}
+}
+
+func newChainError(message string) *ChainError {
+	return &ChainError{Code: chain.ErrorCode_failure, Message: message, OperationIndex: -1}
+}
+
+// chainErrorFromData creates a ChainError from the message and data of a JSON-RPC error.
+// The data is either a JSON object or a string containing one.
+func chainErrorFromData(message string, data interface{}) *ChainError {
+	e := newChainError(message)
+
+	var raw []byte
+	switch d := data.(type) {
+	case nil:
+		return e
+	case string:
+		raw = []byte(d)
+	default:
+		var err error
+		if raw, err = json.Marshal(d); err != nil {
+			return e
+		}
+	}
+
+	details := &errorData{}
+	if err := json.Unmarshal(raw, details); err == nil {
+		details.apply(e)
+	}
+
+	return e
+}
+
+// chainErrorFromStatus creates a ChainError from a microservice error response
+func chainErrorFromStatus(status *rpc.ErrorStatus) *ChainError {
+	e := chainErrorFromData(status.GetMessage(), status.GetData())
+
+	for _, detail := range status.GetDetails() {
+		errorDetails := &chain.ErrorDetails{}
+		if detail.MessageIs(errorDetails) && detail.UnmarshalTo(errorDetails) == nil {
+			e.Code = chain.ErrorCode(errorDetails.GetCode())
+			if len(errorDetails.GetLogs()) > 0 {
+				e.Logs = errorDetails.GetLogs()
+			}
+		}
+	}
+
+	return e
+}
+
+// AsChainError returns the ChainError wrapped by err, decoding JSON-RPC errors of other clients
+//
+// Errors from kjsonrpc.KoinosRPCClient do not carry an error code and are converted with
+// Code set to chain.ErrorCode_failure. Use NewJSONRPCClient or NewClientFromEnv for fully
+// typed errors.
+func AsChainError(err error) (*ChainError, bool) {
+	var chainErr *ChainError
+	if errors.As(err, &chainErr) {
+		return chainErr, true
+	}
+
+	var jsonErr *jsonrpc.RPCError
+	if errors.As(err, &jsonErr) {
+		return chainErrorFromData(jsonErr.Message, jsonErr.Data), true
+	}
+
+	var rpcErr kjsonrpc.KoinosRPCError
+	if errors.As(err, &rpcErr) {
+		e := newChainError(rpcErr.Error())
+		e.Logs = rpcErr.Logs
+		return e, true
+	}
+
+	return nil, false
+}
+
+// decodeChainError returns the node errors of any client as *ChainError, leaving other errors,
+// such as connection failures, unchanged. Every transport and every client bound with
+// WithContext or ForTest returns its errors through it.
+func decodeChainError(err error) error {
+	var chainErr *ChainError
+	if err == nil || errors.As(err, &chainErr) {
+		return err
+	}
+
+	if e, ok := AsChainError(err); ok {
+		return e
+	}
+
+	return err
+}
+
+// IsReversion returns true if err is a transaction reversion
+func IsReversion(err error) bool {
+	e, ok := AsChainError(err)
+	return ok && e.Code > chain.ErrorCode_success
+}
+
+// IsFailure returns true if err is a transaction or block failure
+func IsFailure(err error) bool {
+	e, ok := AsChainError(err)
+	return ok && e.Code < chain.ErrorCode_success
+}
+
+// IsInsufficientRc returns true if err was caused by the payer not having enough resource credits
+func IsInsufficientRc(err error) bool {
+	return hasCode(err, chain.ErrorCode_insufficient_rc)
+}
+
+// IsInvalidNonce returns true if err was caused by an invalid transaction nonce
+func IsInvalidNonce(err error) bool {
+	return hasCode(err, chain.ErrorCode_invalid_nonce)
+}
+
+// IsInvalidSignature returns true if err was caused by an invalid signature
+func IsInvalidSignature(err error) bool {
+	return hasCode(err, chain.ErrorCode_invalid_signature)
+}
+
+func hasCode(err error, code chain.ErrorCode) bool {
+	e, ok := AsChainError(err)
+	return ok && e.Code == code
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc"
+	chainrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	"github.com/stretchr/testify/require"
+	jsonrpc "github.com/ybbus/jsonrpc/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestChainErrorTransports(t *testing.T) {
+	expected := &ChainError{
+		Code:           chain.ErrorCode_invalid_nonce,
+		Message:        "invalid nonce",
+		Logs:           []string{"expected nonce 2"},
+		OperationIndex: -1,
+	}
+
+	t.Logf("Decoding a JSON-RPC error")
+	jsonErr := chainErrorFromData("invalid nonce", `{"code":-201,"logs":["expected nonce 2"]}`)
+	require.Equal(t, expected, jsonErr)
+	require.True(t, IsInvalidNonce(jsonErr))
+	require.True(t, IsFailure(jsonErr))
+	require.False(t, IsReversion(jsonErr))
+
+	t.Logf("Decoding an MQ error")
+	details, err := anypb.New(&chain.ErrorDetails{Code: int32(chain.ErrorCode_invalid_nonce), Logs: expected.Logs})
+	require.NoError(t, err)
+
+	resBytes, err := proto.Marshal(&chainrpc.ChainResponse{
+		Response: &chainrpc.ChainResponse_Error{
+			Error: &rpc.ErrorStatus{Message: "invalid nonce", Details: []*anypb.Any{details}},
+		},
+	})
+	require.NoError(t, err)
+
+	mqErr := translateResponse("chain", resBytes, &chainrpc.SubmitTransactionResponse{})
+	require.Equal(t, expected, mqErr)
+
+	t.Logf("Decoding the operation index of an MQ error")
+	resBytes, err = proto.Marshal(&chainrpc.ChainResponse{
+		Response: &chainrpc.ChainResponse_Error{
+			Error: &rpc.ErrorStatus{Message: "a reversion has occurred", Data: `{"operation_index":1}`, Details: []*anypb.Any{details}},
+		},
+	})
+	require.NoError(t, err)
+
+	chainErr, ok := AsChainError(translateResponse("chain", resBytes, &chainrpc.SubmitTransactionResponse{}))
+	require.True(t, ok)
+	require.Equal(t, 1, chainErr.OperationIndex)
+
+	t.Logf("Decoding the JSON-RPC errors of other clients bound to a test")
+	client := ForTest(t, errorClient{&jsonrpc.RPCError{Code: -32603, Message: "insufficient rc", Data: `{"code":104,"operation_index":0}`}})
+	err = client.Call(context.Background(), GetHeadInfoCall, &chainrpc.GetHeadInfoRequest{}, &chainrpc.GetHeadInfoResponse{})
+
+	chainErr, ok = err.(*ChainError)
+	require.True(t, ok, "Expected *ChainError, was %T", err)
+	require.True(t, IsInsufficientRc(err))
+	require.Equal(t, 0, chainErr.OperationIndex)
+
+	t.Logf("Leaving other errors unchanged")
+	client = ForTest(t, errorClient{context.Canceled})
+	err = client.Call(context.Background(), GetHeadInfoCall, &chainrpc.GetHeadInfoRequest{}, &chainrpc.GetHeadInfoResponse{})
+	require.ErrorIs(t, err, context.Canceled)
+	_, ok = AsChainError(err)
+	require.False(t, ok)
+}
+
+// errorClient fails every call with err
+type errorClient struct {
+	err error
+}
+
+func (c errorClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	return c.err
+}
+
+func TestChainErrorFromData(t *testing.T) {
+	t.Logf("Reversion with object data")
+	err := chainErrorFromData("a reversion has occurred", map[string]interface{}{"code": 1, "logs": []string{"log"}, "operation_index": 2})
+	require.True(t, IsReversion(err))
+	require.Equal(t, []string{"log"}, err.Logs)
+	require.Equal(t, 2, err.OperationIndex)
+
+	t.Logf("Missing code")
+	err = chainErrorFromData("unknown", `{"logs":["log"]}`)
+	require.Equal(t, chain.ErrorCode_failure, err.Code)
+	require.Equal(t, []string{"log"}, err.Logs)
+
+	t.Logf("Insufficient rc")
+	require.True(t, IsInsufficientRc(chainErrorFromData("insufficient rc", `{"code":104}`)))
+
+	t.Logf("Invalid signature")
+	require.True(t, IsInvalidSignature(chainErrorFromData("invalid signature", `{"code":-202}`)))
+
+	require.False(t, IsFailure(nil))
+}
@@ -0,0 +1,185 @@
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/governance"
+	name_service "github.com/koinos/koinos-proto-golang/v2/koinos/contracts/name-service"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/pob"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/standards/kcs4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Event names emitted by the system contracts
+const (
+	TokenTransferEvent      = "token.transfer_event"
+	TokenMintEvent          = "token.mint_event"
+	TokenBurnEvent          = "token.burn_event"
+	TokenApproveEvent       = "token.approve_event"
+	RegisterPublicKeyEvent  = "koinos.contracts.pob.register_public_key_event"
+	ProposalSubmissionEvent = "koinos.contracts.governance.proposal_submission_event"
+	ProposalStatusEvent     = "koinos.contracts.governance.proposal_status_event"
+	ProposalVoteEvent       = "koinos.contracts.governance.proposal_vote_event"
+	RecordUpdateEvent       = "koinos.contracts.record_update_event"
+)
+
+type eventKey struct {
+	name   string
+	source string
+}
+
+// EventRegistry maps event names and sources to the proto message of the event data
+type EventRegistry struct {
+	mu    sync.RWMutex
+	types map[eventKey]protoreflect.MessageType
+}
+
+// NewEventRegistry creates an empty EventRegistry
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{types: make(map[eventKey]protoreflect.MessageType)}
+}
+
+// Register maps events with name emitted by source to the type of message.
+// A nil source matches events from any contract.
+func (r *EventRegistry) Register(name string, source []byte, message proto.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.types[eventKey{name: name, source: string(source)}] = message.ProtoReflect().Type()
+}
+
+// Lookup returns the message type registered for an event, preferring a registration for its source
+func (r *EventRegistry) Lookup(name string, source []byte) (protoreflect.MessageType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if messageType, ok := r.types[eventKey{name: name, source: string(source)}]; ok {
+		return messageType, true
+	}
+
+	messageType, ok := r.types[eventKey{name: name}]
+	return messageType, ok
+}
+
+// Decode decodes the data of an event. Events without a registered type decode to a nil Message.
+func (r *EventRegistry) Decode(event *protocol.EventData) (*DecodedEvent, error) {
+	decoded := &DecodedEvent{EventData: event}
+
+	messageType, ok := r.Lookup(event.Name, event.Source)
+	if !ok {
+		return decoded, nil
+	}
+
+	decoded.Message = messageType.New().Interface()
+	if err := proto.Unmarshal(event.Data, decoded.Message); err != nil {
+		return nil, fmt.Errorf("decoding %s from %s: %w", event.Name, base58.Encode(event.Source), err)
+	}
+
+	return decoded, nil
+}
+
+// DecodedEvent is an event with its data decoded
+type DecodedEvent struct {
+	*protocol.EventData
+	Message proto.Message
+}
+
+// String returns the event name, source and data as JSON
+func (e *DecodedEvent) String() string {
+	if e.Message == nil {
+		return fmt.Sprintf("%s from %s", e.Name, base58.Encode(e.Source))
+	}
+
+	data, err := protojson.Marshal(e.Message)
+	if err != nil {
+		data = []byte(err.Error())
+	}
+
+	return fmt.Sprintf("%s from %s: %s", e.Name, base58.Encode(e.Source), data)
+}
+
+// DefaultEventRegistry knows the events of the system contracts. KCS-4 token events are
+// registered for every source so they decode for KOIN, VHP and uploaded tokens alike.
+var DefaultEventRegistry = newDefaultEventRegistry()
+
+func newDefaultEventRegistry() *EventRegistry {
+	r := NewEventRegistry()
+
+	r.Register(TokenTransferEvent, nil, &kcs4.TransferEvent{})
+	r.Register(TokenMintEvent, nil, &kcs4.MintEvent{})
+	r.Register(TokenBurnEvent, nil, &kcs4.BurnEvent{})
+	r.Register(TokenApproveEvent, nil, &kcs4.ApproveEvent{})
+	r.Register(RegisterPublicKeyEvent, nil, &pob.RegisterPublicKeyEvent{})
+	r.Register(ProposalSubmissionEvent, nil, &governance.ProposalSubmissionEvent{})
+	r.Register(ProposalStatusEvent, nil, &governance.ProposalStatusEvent{})
+	r.Register(ProposalVoteEvent, nil, &governance.ProposalVoteEvent{})
+	r.Register(RecordUpdateEvent, nil, &name_service.RecordUpdateEvent{})
+
+	return r
+}
+
+// RegisterEvent registers an event type with the DefaultEventRegistry
+func RegisterEvent(name string, source []byte, message proto.Message) {
+	DefaultEventRegistry.Register(name, source, message)
+}
+
+// DecodeEvents decodes the events of a *protocol.BlockReceipt or *protocol.TransactionReceipt
+// using the DefaultEventRegistry. Block receipt events include those of its transactions, in
+// sequence order.
+func DecodeEvents(receipt proto.Message) ([]*DecodedEvent, error) {
+	var events []*protocol.EventData
+
+	switch r := receipt.(type) {
+	case *protocol.BlockReceipt:
+		events = EventsFromBlockReceipt(r)
+	case *protocol.TransactionReceipt:
+		events = r.Events
+	default:
+		return nil, fmt.Errorf("unexpected receipt type %T", receipt)
+	}
+
+	decoded := make([]*DecodedEvent, 0, len(events))
+	for _, event := range events {
+		d, err := DefaultEventRegistry.Decode(event)
+		if err != nil {
+			return nil, err
+		}
+
+		decoded = append(decoded, d)
+	}
+
+	return decoded, nil
+}
+
+// RequireEvent asserts the receipt contains an event equal to expected, returning the event.
+// Optionally, the event source must match.
+func RequireEvent(t *testing.T, receipt proto.Message, expected proto.Message, source ...[]byte) *DecodedEvent {
+	events, err := DecodeEvents(receipt)
+	NoError(t, err)
+
+	for _, event := range events {
+		if len(source) > 0 && !bytes.Equal(source[0], event.Source) {
+			continue
+		}
+
+		if event.Message != nil && proto.Equal(event.Message, expected) {
+			return event
+		}
+	}
+
+	expectedJSON, _ := protojson.Marshal(expected)
+	for _, event := range events {
+		t.Logf(event.String())
+	}
+	require.FailNow(t, "missing event", "expected %s %s", expected.ProtoReflect().Descriptor().FullName(), expectedJSON)
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/governance"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/pob"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/standards/kcs4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func eventData(t *testing.T, sequence uint32, name string, source []byte, message proto.Message) *protocol.EventData {
+	data, err := proto.Marshal(message)
+	require.NoError(t, err)
+
+	return &protocol.EventData{Sequence: sequence, Name: name, Source: source, Data: data}
+}
+
+func TestDecodeEvents(t *testing.T) {
+	koinKey, err := integration.GetKey(integration.Koin)
+	require.NoError(t, err)
+	pobKey, err := integration.GetKey(integration.Pob)
+	require.NoError(t, err)
+	governanceKey, err := integration.GetKey(integration.Governance)
+	require.NoError(t, err)
+	alice, err := integration.GetKey(integration.Genesis)
+	require.NoError(t, err)
+
+	burn := &kcs4.BurnEvent{From: alice.AddressBytes(), Value: 100}
+	register := &pob.RegisterPublicKeyEvent{Address: alice.AddressBytes(), PublicKey: alice.PublicBytes()}
+	submission := &governance.ProposalSubmissionEvent{Proposal: &governance.ProposalRecord{Fee: 10, Status: governance.ProposalStatus_pending}}
+
+	receipt := &protocol.BlockReceipt{
+		Events: []*protocol.EventData{
+			eventData(t, 3, "custom_event", koinKey.AddressBytes(), &kcs4.BurnEvent{}),
+		},
+		TransactionReceipts: []*protocol.TransactionReceipt{
+			{
+				Events: []*protocol.EventData{
+					eventData(t, 0, integration.TokenBurnEvent, koinKey.AddressBytes(), burn),
+					eventData(t, 1, integration.RegisterPublicKeyEvent, pobKey.AddressBytes(), register),
+					eventData(t, 2, integration.ProposalSubmissionEvent, governanceKey.AddressBytes(), submission),
+				},
+			},
+		},
+	}
+
+	events, err := integration.DecodeEvents(receipt)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	require.True(t, proto.Equal(burn, events[0].Message))
+	require.True(t, proto.Equal(register, events[1].Message))
+	require.True(t, proto.Equal(submission, events[2].Message))
+	require.Nil(t, events[3].Message)
+
+	integration.RequireEvent(t, receipt, burn)
+	integration.RequireEvent(t, receipt.TransactionReceipts[0], register, pobKey.AddressBytes())
+
+	t.Logf("Registering an event for a single source")
+	registry := integration.NewEventRegistry()
+	registry.Register("custom_event", koinKey.AddressBytes(), &kcs4.MintEvent{})
+
+	decoded, err := registry.Decode(receipt.Events[0])
+	require.NoError(t, err)
+	require.IsType(t, &kcs4.MintEvent{}, decoded.Message)
+
+	decoded, err = registry.Decode(&protocol.EventData{Name: "custom_event", Source: pobKey.AddressBytes()})
+	require.NoError(t, err)
+	require.Nil(t, decoded.Message)
+}
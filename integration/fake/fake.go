@@ -0,0 +1,642 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"koinos-integration-tests/integration"
+	"sync"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/canonical"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	block_store_rpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
+	chainrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	mempoolrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/mempool"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/multiformats/go-multihash"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultRc is the rc every account has unless overridden with SetAccountRc
+	DefaultRc uint64 = 10000000000
+
+	// RcPerOperation is the rc a transaction uses for each of its operations
+	RcPerOperation uint64 = 100000
+)
+
+var (
+	// ErrUnsupportedMethod is returned when the fake chain does not implement a method
+	ErrUnsupportedMethod = errors.New("unsupported method")
+
+	// ErrInvalidBlock is returned when a submitted block fails validation
+	ErrInvalidBlock = errors.New("invalid block")
+
+	// ErrInvalidTransaction is returned when a submitted transaction fails validation
+	ErrInvalidTransaction = errors.New("invalid transaction")
+)
+
+// rejection is a validation error that matches both its sentinel error with errors.Is
+// and *integration.ChainError with errors.As
+type rejection struct {
+	code chain.ErrorCode
+	err  error
+}
+
+func reject(code chain.ErrorCode, format string, args ...interface{}) error {
+	return &rejection{code: code, err: fmt.Errorf(format, args...)}
+}
+
+func (r *rejection) Error() string {
+	return r.err.Error()
+}
+
+func (r *rejection) Unwrap() error {
+	return r.err
+}
+
+func (r *rejection) As(target interface{}) bool {
+	if chainErr, ok := target.(**integration.ChainError); ok {
+		*chainErr = &integration.ChainError{Code: r.code, Message: r.err.Error(), OperationIndex: -1}
+		return true
+	}
+
+	return false
+}
+
+// blockEntry is a block in the tree along with the state after applying it
+type blockEntry struct {
+	block     *protocol.Block
+	receipt   *protocol.BlockReceipt
+	topology  *koinos.BlockTopology
+	time      uint64
+	stateRoot []byte
+	nonces    map[string]uint64
+	parent    *blockEntry
+	children  int
+}
+
+// Client is an in-memory chain that implements integration.Client
+//
+// It keeps a tree of blocks with the account nonces after each block, and a mempool. The
+// highest block is the head, keeping the current head on a tie. When the head moves to
+// another branch, transactions of the orphaned blocks return to the mempool. Contracts are
+// never executed, so every transaction succeeds with an empty receipt once it is valid,
+// using RcPerOperation rc for each operation.
+type Client struct {
+	mu sync.Mutex
+
+	chainID    []byte
+	genesis    *blockEntry
+	head       *blockEntry
+	entries    []*blockEntry
+	blocksByID map[string]*blockEntry
+	rc         map[string]uint64
+	pending    []*protocol.Transaction
+}
+
+// NewClient returns a fake chain at height 0
+func NewClient() *Client {
+	chainID, _ := multihash.Encode(hash([]byte("koinos-integration-tests")), multihash.SHA2_256)
+	genesisID, _ := multihash.Encode(make([]byte, sha256.Size), multihash.SHA2_256)
+	stateRoot, _ := multihash.Encode(hash(nil), multihash.SHA2_256)
+
+	genesis := &blockEntry{
+		topology:  &koinos.BlockTopology{Id: genesisID, Height: 0},
+		stateRoot: stateRoot,
+		nonces:    make(map[string]uint64),
+	}
+
+	return &Client{
+		chainID:    chainID,
+		genesis:    genesis,
+		head:       genesis,
+		blocksByID: map[string]*blockEntry{string(genesisID): genesis},
+		rc:         make(map[string]uint64),
+	}
+}
+
+// SetAccountRc sets the rc available to an account
+func (c *Client) SetAccountRc(address []byte, rc uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rc[string(address)] = rc
+}
+
+// Blocks returns the blocks from genesis to the head, ordered by height
+func (c *Client) Blocks() []*protocol.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	branch := c.branch(c.head)
+	blocks := make([]*protocol.Block, len(branch))
+	for i, entry := range branch {
+		blocks[i] = entry.block
+	}
+
+	return blocks
+}
+
+// branch returns the blocks from genesis to entry, excluding genesis
+func (c *Client) branch(entry *blockEntry) []*blockEntry {
+	branch := make([]*blockEntry, entry.topology.Height)
+	for ; entry != c.genesis; entry = entry.parent {
+		branch[entry.topology.Height-1] = entry
+	}
+
+	return branch
+}
+
+// Call implements integration.Client
+func (c *Client) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resp proto.Message
+	var err error
+
+	switch method {
+	case integration.GetHeadInfoCall:
+		resp = c.getHeadInfo()
+	case integration.GetChainIDCall:
+		resp = &chainrpc.GetChainIdResponse{ChainId: c.chainID}
+	case integration.GetAccountNonceCall:
+		resp, err = c.getAccountNonce(params)
+	case integration.GetAccountRcCall:
+		resp, err = c.getAccountRc(params)
+	case integration.SubmitBlockCall:
+		resp, err = c.submitBlock(params)
+	case integration.SubmitTransactionCall:
+		resp, err = c.submitTransaction(params)
+	case integration.GetPendingNonceCall:
+		resp, err = c.getPendingNonce(params)
+	case integration.GetPendingTransactionsCall:
+		resp, err = c.getPendingTransactions(params)
+	case integration.GetForkHeadsCall:
+		resp = c.getForkHeads()
+	case integration.GetBlocksByHeightCall:
+		resp, err = c.getBlocksByHeight(params)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedMethod, method)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	proto.Reset(returnType)
+	proto.Merge(returnType, resp)
+
+	return nil
+}
+
+func (c *Client) getHeadInfo() *chainrpc.GetHeadInfoResponse {
+	return &chainrpc.GetHeadInfoResponse{
+		HeadTopology:        proto.Clone(c.head.topology).(*koinos.BlockTopology),
+		HeadStateMerkleRoot: c.head.stateRoot,
+		HeadBlockTime:       c.head.time,
+	}
+}
+
+func (c *Client) getForkHeads() *chainrpc.GetForkHeadsResponse {
+	resp := &chainrpc.GetForkHeadsResponse{LastIrreversibleBlock: proto.Clone(c.genesis.topology).(*koinos.BlockTopology)}
+	for _, entry := range c.entries {
+		if entry.children == 0 {
+			resp.ForkHeads = append(resp.ForkHeads, proto.Clone(entry.topology).(*koinos.BlockTopology))
+		}
+	}
+
+	return resp
+}
+
+func (c *Client) getAccountNonce(params proto.Message) (proto.Message, error) {
+	req, ok := params.(*chainrpc.GetAccountNonceRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", params)
+	}
+
+	nonce, err := util.UInt64ToNonceBytes(c.head.nonces[string(req.Account)])
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainrpc.GetAccountNonceResponse{Nonce: nonce}, nil
+}
+
+func (c *Client) getAccountRc(params proto.Message) (proto.Message, error) {
+	req, ok := params.(*chainrpc.GetAccountRcRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", params)
+	}
+
+	return &chainrpc.GetAccountRcResponse{Rc: c.accountRc(req.Account)}, nil
+}
+
+func (c *Client) accountRc(address []byte) uint64 {
+	if rc, ok := c.rc[string(address)]; ok {
+		return rc
+	}
+
+	return DefaultRc
+}
+
+func (c *Client) submitTransaction(params proto.Message) (proto.Message, error) {
+	req, ok := params.(*chainrpc.SubmitTransactionRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", params)
+	}
+
+	// The mempool accepts a sequence of nonces on top of any pending transactions
+	if err := c.validateTransaction(req.GetTransaction(), c.pendingNonce(nonceAccount(req.GetTransaction()))); err != nil {
+		return nil, err
+	}
+
+	// Without broadcast the transaction is only checked, as a trial
+	if req.Broadcast {
+		c.pending = append(c.pending, req.GetTransaction())
+	}
+
+	return &chainrpc.SubmitTransactionResponse{Receipt: transactionReceipt(req.GetTransaction())}, nil
+}
+
+func (c *Client) getPendingNonce(params proto.Message) (proto.Message, error) {
+	req, ok := params.(*mempoolrpc.GetPendingNonceRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", params)
+	}
+
+	nonce, err := util.UInt64ToNonceBytes(c.pendingNonce(req.Payee))
+	if err != nil {
+		return nil, err
+	}
+
+	return &mempoolrpc.GetPendingNonceResponse{Nonce: nonce}, nil
+}
+
+// pendingNonce returns the highest nonce of the account's pending transactions or its account nonce
+func (c *Client) pendingNonce(account []byte) uint64 {
+	nonce := c.head.nonces[string(account)]
+	for _, tx := range c.pending {
+		if !bytes.Equal(nonceAccount(tx), account) {
+			continue
+		}
+
+		if n, _ := util.NonceBytesToUInt64(tx.GetHeader().GetNonce()); n > nonce {
+			nonce = n
+		}
+	}
+
+	return nonce
+}
+
+func (c *Client) getPendingTransactions(params proto.Message) (proto.Message, error) {
+	req, ok := params.(*mempoolrpc.GetPendingTransactionsRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", params)
+	}
+
+	resp := &mempoolrpc.GetPendingTransactionsResponse{}
+	for _, tx := range c.pending {
+		if req.Limit > 0 && uint64(len(resp.PendingTransactions)) >= req.Limit {
+			break
+		}
+
+		resp.PendingTransactions = append(resp.PendingTransactions, &mempoolrpc.PendingTransaction{Transaction: tx})
+	}
+
+	return resp, nil
+}
+
+func (c *Client) getBlocksByHeight(params proto.Message) (proto.Message, error) {
+	req, ok := params.(*block_store_rpc.GetBlocksByHeightRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", params)
+	}
+
+	headEntry, ok := c.blocksByID[string(req.HeadBlockId)]
+	if !ok {
+		return nil, fmt.Errorf("unknown head block %s", base58.Encode(req.HeadBlockId))
+	}
+
+	branch := c.branch(headEntry)
+
+	resp := &block_store_rpc.GetBlocksByHeightResponse{}
+	for i := uint32(0); i < req.NumBlocks; i++ {
+		height := req.AncestorStartHeight + uint64(i)
+		if height == 0 || height > headEntry.topology.Height {
+			break
+		}
+
+		entry := branch[height-1]
+		item := &block_store_rpc.BlockItem{BlockId: entry.block.Id, BlockHeight: height}
+		if req.ReturnBlock {
+			item.Block = entry.block
+		}
+		if req.ReturnReceipt {
+			item.Receipt = entry.receipt
+		}
+
+		resp.BlockItems = append(resp.BlockItems, item)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) submitBlock(params proto.Message) (proto.Message, error) {
+	req, ok := params.(*chainrpc.SubmitBlockRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", params)
+	}
+
+	block := req.GetBlock()
+	if entry, ok := c.blocksByID[string(block.GetId())]; ok {
+		return &chainrpc.SubmitBlockResponse{Receipt: entry.receipt}, nil
+	}
+
+	parent, err := c.validateBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transactions are applied against a copy of the nonces so a bad transaction leaves no trace
+	nonces := make(map[string]uint64, len(parent.nonces))
+	for k, v := range parent.nonces {
+		nonces[k] = v
+	}
+
+	receipt := &protocol.BlockReceipt{Id: block.Id, Height: block.Header.Height}
+	for i, tx := range block.Transactions {
+		account := string(nonceAccount(tx))
+		if err := c.validateTransaction(tx, nonces[account]); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		nonces[account]++
+		receipt.TransactionReceipts = append(receipt.TransactionReceipts, transactionReceipt(tx))
+	}
+
+	// Only transactions change the state, so an empty block keeps the state merkle root of its parent
+	stateRoot := parent.stateRoot
+	if len(block.Transactions) > 0 {
+		stateRoot, err = multihash.Encode(hash(append(append([]byte{}, parent.stateRoot...), block.Id...)), multihash.SHA2_256)
+		if err != nil {
+			return nil, err
+		}
+	}
+	receipt.StateMerkleRoot = stateRoot
+
+	entry := &blockEntry{
+		block:     block,
+		receipt:   receipt,
+		topology:  &koinos.BlockTopology{Id: block.Id, Height: block.Header.Height, Previous: block.Header.Previous},
+		time:      block.Header.Timestamp,
+		stateRoot: stateRoot,
+		nonces:    nonces,
+		parent:    parent,
+	}
+
+	parent.children++
+	c.entries = append(c.entries, entry)
+	c.blocksByID[string(block.Id)] = entry
+
+	if entry.topology.Height > c.head.topology.Height {
+		c.setHead(entry)
+	}
+
+	return &chainrpc.SubmitBlockResponse{Receipt: receipt}, nil
+}
+
+// setHead moves the head to entry. Transactions of blocks orphaned by the move return to the
+// mempool, which then drops every transaction whose nonce is no longer next for its account.
+func (c *Client) setHead(entry *blockEntry) {
+	onBranch := make(map[*blockEntry]struct{})
+	for e := entry; e != nil; e = e.parent {
+		onBranch[e] = struct{}{}
+	}
+
+	var orphaned []*blockEntry
+	for e := c.head; e != nil; e = e.parent {
+		if _, ok := onBranch[e]; ok {
+			break
+		}
+
+		orphaned = append([]*blockEntry{e}, orphaned...)
+	}
+
+	candidates := make([]*protocol.Transaction, 0, len(c.pending))
+	for _, e := range orphaned {
+		candidates = append(candidates, e.block.Transactions...)
+	}
+	candidates = append(candidates, c.pending...)
+
+	c.head = entry
+
+	nonces := make(map[string]uint64)
+	pending := make([]*protocol.Transaction, 0, len(candidates))
+	for _, tx := range candidates {
+		account := string(nonceAccount(tx))
+		if _, ok := nonces[account]; !ok {
+			nonces[account] = entry.nonces[account]
+		}
+
+		if nonce, _ := util.NonceBytesToUInt64(tx.GetHeader().GetNonce()); nonce == nonces[account]+1 {
+			nonces[account] = nonce
+			pending = append(pending, tx)
+		}
+	}
+
+	c.pending = pending
+}
+
+// validateBlock checks a block, returning its parent
+func (c *Client) validateBlock(block *protocol.Block) (*blockEntry, error) {
+	header := block.GetHeader()
+	if header == nil {
+		return nil, reject(chain.ErrorCode_malformed_block, "%w: missing header", ErrInvalidBlock)
+	}
+
+	parent, ok := c.blocksByID[string(header.Previous)]
+	if !ok {
+		return nil, reject(chain.ErrorCode_unknown_previous_block, "%w: unknown previous block %s", ErrInvalidBlock, base58.Encode(header.Previous))
+	}
+
+	if header.Height != parent.topology.Height+1 {
+		return nil, reject(chain.ErrorCode_unexpected_height, "%w: expected height %d, was %d", ErrInvalidBlock, parent.topology.Height+1, header.Height)
+	}
+
+	if header.Timestamp < parent.time {
+		return nil, reject(chain.ErrorCode_timestamp_out_of_bounds, "%w: timestamp %d precedes previous block time %d", ErrInvalidBlock, header.Timestamp, parent.time)
+	}
+
+	if !bytes.Equal(header.PreviousStateMerkleRoot, parent.stateRoot) {
+		return nil, reject(chain.ErrorCode_state_merkle_mismatch, "%w: unexpected previous state merkle root", ErrInvalidBlock)
+	}
+
+	merkleRoot, err := transactionMerkleRoot(block.Transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(header.TransactionMerkleRoot, merkleRoot) {
+		return nil, reject(chain.ErrorCode_malformed_block, "%w: transaction merkle root mismatch", ErrInvalidBlock)
+	}
+
+	id, err := headerID(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(block.Id, id) {
+		return nil, reject(chain.ErrorCode_malformed_block, "%w: block id does not match header", ErrInvalidBlock)
+	}
+
+	signer, err := integration.RecoverAddress(block.Id, block.Signature)
+	if err != nil {
+		return nil, reject(chain.ErrorCode_invalid_signature, "%w: %s", ErrInvalidBlock, err.Error())
+	}
+
+	if !bytes.Equal(signer, header.Signer) {
+		return nil, reject(chain.ErrorCode_invalid_signature, "%w: block signed by %s, expected %s", ErrInvalidBlock, base58.Encode(signer), base58.Encode(header.Signer))
+	}
+
+	return parent, nil
+}
+
+// validateTransaction checks a transaction that should follow the given account nonce
+func (c *Client) validateTransaction(tx *protocol.Transaction, accountNonce uint64) error {
+	header := tx.GetHeader()
+	if header == nil {
+		return reject(chain.ErrorCode_malformed_transaction, "%w: missing header", ErrInvalidTransaction)
+	}
+
+	if !bytes.Equal(header.ChainId, c.chainID) {
+		return reject(chain.ErrorCode_malformed_transaction, "%w: unexpected chain id", ErrInvalidTransaction)
+	}
+
+	merkleRoot, err := integration.CalculateOperationMerkleRoot(tx.Operations)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(header.OperationMerkleRoot, merkleRoot) {
+		return reject(chain.ErrorCode_malformed_transaction, "%w: operation merkle root mismatch", ErrInvalidTransaction)
+	}
+
+	id, err := headerID(header)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(tx.Id, id) {
+		return reject(chain.ErrorCode_malformed_transaction, "%w: transaction id does not match header", ErrInvalidTransaction)
+	}
+
+	nonce, err := util.NonceBytesToUInt64(header.Nonce)
+	if err != nil {
+		return reject(chain.ErrorCode_malformed_transaction, "%w: %s", ErrInvalidTransaction, err.Error())
+	}
+
+	if nonce != accountNonce+1 {
+		return reject(chain.ErrorCode_invalid_nonce, "%w: invalid nonce %d, expected %d", ErrInvalidTransaction, nonce, accountNonce+1)
+	}
+
+	if header.RcLimit > c.accountRc(header.Payer) {
+		return reject(chain.ErrorCode_insufficient_rc, "%w: rc limit %d exceeds payer rc %d", ErrInvalidTransaction, header.RcLimit, c.accountRc(header.Payer))
+	}
+
+	if rcUsed := rcUsed(tx); header.RcLimit < rcUsed {
+		return reject(chain.ErrorCode_insufficient_rc, "%w: rc limit %d is below rc used %d", ErrInvalidTransaction, header.RcLimit, rcUsed)
+	}
+
+	payerSigned, payeeSigned := false, len(header.Payee) == 0
+	for _, sig := range tx.Signatures {
+		signer, err := integration.RecoverAddress(tx.Id, sig)
+		if err != nil {
+			return reject(chain.ErrorCode_invalid_signature, "%w: %s", ErrInvalidTransaction, err.Error())
+		}
+
+		payerSigned = payerSigned || bytes.Equal(signer, header.Payer)
+		payeeSigned = payeeSigned || bytes.Equal(signer, header.Payee)
+	}
+
+	if !payerSigned {
+		return reject(chain.ErrorCode_invalid_signature, "%w: transaction not signed by payer %s", ErrInvalidTransaction, base58.Encode(header.Payer))
+	}
+
+	if !payeeSigned {
+		return reject(chain.ErrorCode_invalid_signature, "%w: transaction not signed by payee %s", ErrInvalidTransaction, base58.Encode(header.Payee))
+	}
+
+	return nil
+}
+
+// nonceAccount returns the account whose nonce a transaction consumes
+func nonceAccount(tx *protocol.Transaction) []byte {
+	if len(tx.GetHeader().GetPayee()) > 0 {
+		return tx.Header.Payee
+	}
+
+	return tx.GetHeader().GetPayer()
+}
+
+func transactionReceipt(tx *protocol.Transaction) *protocol.TransactionReceipt {
+	return &protocol.TransactionReceipt{
+		Id:         tx.Id,
+		Payer:      tx.Header.Payer,
+		MaxPayerRc: tx.Header.RcLimit,
+		RcLimit:    tx.Header.RcLimit,
+		RcUsed:     rcUsed(tx),
+	}
+}
+
+// rcUsed returns the rc charged for a transaction
+func rcUsed(tx *protocol.Transaction) uint64 {
+	return RcPerOperation * uint64(len(tx.Operations))
+}
+
+func transactionMerkleRoot(transactions []*protocol.Transaction) ([]byte, error) {
+	if len(transactions) == 0 {
+		return multihash.Encode(hash(nil), multihash.SHA2_256)
+	}
+
+	hashes := make([][]byte, 0, len(transactions)*2)
+	for _, tx := range transactions {
+		var sigs []byte
+		for _, sig := range tx.Signatures {
+			sigs = append(sigs, sig...)
+		}
+
+		sum, err := multihash.Encode(hash(sigs), multihash.SHA2_256)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, tx.Id, sum)
+	}
+
+	return util.CalculateMerkleRoot(hashes)
+}
+
+func headerID(header proto.Message) ([]byte, error) {
+	headerBytes, err := canonical.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return multihash.Encode(hash(headerBytes), multihash.SHA2_256)
+}
+
+func hash(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
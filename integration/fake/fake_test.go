@@ -0,0 +1,136 @@
+package fake
+
+import (
+	"koinos-integration-tests/integration"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func uploadOperation(key *util.KoinosKey) *protocol.Operation {
+	return &protocol.Operation{
+		Op: &protocol.Operation_UploadContract{
+			UploadContract: &protocol.UploadContractOperation{
+				ContractId: key.AddressBytes(),
+			},
+		},
+	}
+}
+
+func TestCreateBlocks(t *testing.T) {
+	client := NewClient()
+
+	receipts, err := integration.CreateBlocks(client, 3)
+	require.NoError(t, err)
+	require.Len(t, receipts, 3)
+
+	headInfo, err := integration.GetHeadInfo(client)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, headInfo.HeadTopology.Height)
+	require.EqualValues(t, receipts[2].Id, headInfo.HeadTopology.Id)
+	require.EqualValues(t, receipts[2].StateMerkleRoot, headInfo.HeadStateMerkleRoot)
+
+	blocks, err := integration.GetBlocksByHeight(client, headInfo.HeadTopology.Id, 2, 5, true, true)
+	require.NoError(t, err)
+	require.Len(t, blocks.BlockItems, 2)
+	require.EqualValues(t, 2, blocks.BlockItems[0].BlockHeight)
+	require.EqualValues(t, receipts[1].Id, blocks.BlockItems[0].Block.Id)
+	require.EqualValues(t, receipts[2].Id, blocks.BlockItems[1].Receipt.Id)
+}
+
+func TestTransactionNonces(t *testing.T) {
+	client := NewClient()
+
+	key, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	tx, err := integration.CreateTransaction(client, []*protocol.Operation{uploadOperation(key)}, key)
+	require.NoError(t, err)
+
+	_, err = integration.SubmitTransaction(client, tx)
+	require.NoError(t, err)
+
+	pending, err := integration.GetPendingTransactions(client, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	pendingNonce, err := integration.GetPendingNonce(client, key.AddressBytes())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, pendingNonce)
+
+	// The chain nonce is unchanged until the block is produced, so this reuses the pending nonce
+	duplicate, err := integration.CreateTransaction(client, []*protocol.Operation{}, key)
+	require.NoError(t, err)
+
+	_, err = integration.SubmitTransaction(client, duplicate)
+	require.ErrorIs(t, err, ErrInvalidTransaction)
+	require.True(t, integration.IsInvalidNonce(err))
+
+	receipt, err := integration.CreateBlock(client, []*protocol.Transaction{tx})
+	require.NoError(t, err)
+	require.Len(t, receipt.TransactionReceipts, 1)
+
+	pending, err = integration.GetPendingTransactions(client, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 0)
+
+	nonce, err := integration.GetAccountNonce(client, key.AddressBytes())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, nonce)
+
+	_, err = integration.CreateBlock(client, []*protocol.Transaction{tx})
+	require.ErrorIs(t, err, ErrInvalidTransaction)
+}
+
+func TestBlockValidation(t *testing.T) {
+	client := NewClient()
+
+	key, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	tx, err := integration.CreateTransaction(client, []*protocol.Operation{uploadOperation(key)}, key)
+	require.NoError(t, err)
+
+	t.Run("signer mismatch", func(t *testing.T) {
+		genesisKey, err := integration.GetKey(integration.Genesis)
+		require.NoError(t, err)
+
+		_, err = integration.CreateBlock(client, []*protocol.Transaction{}, key, func(b *protocol.Block) error {
+			b.Header.Signer = genesisKey.AddressBytes()
+			return nil
+		})
+		require.ErrorIs(t, err, ErrInvalidBlock)
+	})
+
+	t.Run("wrong parent", func(t *testing.T) {
+		_, err := integration.CreateBlock(client, []*protocol.Transaction{}, key, func(b *protocol.Block) error {
+			b.Header.Height++
+			return nil
+		})
+		require.ErrorIs(t, err, ErrInvalidBlock)
+	})
+
+	t.Run("unsigned transaction", func(t *testing.T) {
+		unsigned := &protocol.Transaction{Id: tx.Id, Header: tx.Header, Operations: tx.Operations}
+		_, err := integration.CreateBlock(client, []*protocol.Transaction{unsigned})
+		require.ErrorIs(t, err, ErrInvalidTransaction)
+		require.True(t, integration.IsInvalidSignature(err))
+	})
+
+	t.Run("rc limit", func(t *testing.T) {
+		client.SetAccountRc(key.AddressBytes(), tx.Header.RcLimit-1)
+		defer client.SetAccountRc(key.AddressBytes(), DefaultRc)
+
+		_, err := integration.CreateBlock(client, []*protocol.Transaction{tx})
+		require.ErrorIs(t, err, ErrInvalidTransaction)
+		require.True(t, integration.IsInsufficientRc(err))
+	})
+
+	require.Len(t, client.Blocks(), 0)
+
+	_, err = integration.CreateBlock(client, []*protocol.Transaction{tx})
+	require.NoError(t, err)
+	require.Len(t, client.Blocks(), 1)
+}
@@ -0,0 +1,222 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+// maxPendingTransactions is the most pending transactions the mempool returns in one call
+const maxPendingTransactions = 2000
+
+// ForkBlock is a block of a ForkTree
+type ForkBlock struct {
+	Receipt  *protocol.BlockReceipt
+	Block    *protocol.Block
+	Parent   *ForkBlock
+	Children []*ForkBlock
+}
+
+// Id returns the id of the block
+func (b *ForkBlock) Id() []byte {
+	return b.Receipt.GetId()
+}
+
+// Height returns the height of the block
+func (b *ForkBlock) Height() uint64 {
+	return b.Receipt.GetHeight()
+}
+
+// ForkTree creates blocks on arbitrary parents, tracking the branches it creates
+//
+// The tree is rooted at the head block when it is created. Every block has a timestamp later than
+// any block before it in the tree, so blocks with the same parent and transactions have distinct ids.
+type ForkTree struct {
+	client    Client
+	root      *ForkBlock
+	blocks    []*ForkBlock
+	timestamp uint64
+}
+
+// NewForkTree creates a ForkTree rooted at the current head block
+func NewForkTree(client Client) (*ForkTree, error) {
+	headInfo, err := GetHeadInfo(client)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &ForkBlock{
+		Receipt: &protocol.BlockReceipt{
+			Id:              headInfo.HeadTopology.GetId(),
+			Height:          headInfo.HeadTopology.GetHeight(),
+			StateMerkleRoot: headInfo.GetHeadStateMerkleRoot(),
+		},
+	}
+
+	return &ForkTree{
+		client:    client,
+		root:      root,
+		blocks:    []*ForkBlock{root},
+		timestamp: headInfo.GetHeadBlockTime(),
+	}, nil
+}
+
+// Root returns the block the tree is rooted at. Its Block is nil.
+func (f *ForkTree) Root() *ForkBlock {
+	return f.root
+}
+
+// Extend creates a block with the transactions on parent. Options are applied after those of the tree.
+func (f *ForkTree) Extend(parent *ForkBlock, transactions []*protocol.Transaction, opts ...BlockOption) (*ForkBlock, error) {
+	timestamp := uint64(time.Now().UnixMilli())
+	if timestamp <= f.timestamp {
+		timestamp = f.timestamp + 1
+	}
+
+	var block *protocol.Block
+	opts = append([]BlockOption{
+		WithParent(parent.Receipt),
+		WithTimestamp(timestamp),
+		WithBlockMutator(func(b *protocol.Block) error {
+			block = b
+			return nil
+		}),
+	}, opts...)
+
+	receipt, err := CreateBlockWith(f.client, transactions, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	f.timestamp = block.Header.Timestamp
+
+	child := &ForkBlock{Receipt: receipt, Block: block, Parent: parent}
+	parent.Children = append(parent.Children, child)
+	f.blocks = append(f.blocks, child)
+
+	return child, nil
+}
+
+// ExtendN creates a branch of n empty blocks on parent, returning the last block
+func (f *ForkTree) ExtendN(parent *ForkBlock, n int) (*ForkBlock, error) {
+	for i := 0; i < n; i++ {
+		block, err := f.Extend(parent, []*protocol.Transaction{})
+		if err != nil {
+			return nil, err
+		}
+
+		parent = block
+	}
+
+	return parent, nil
+}
+
+// Branch returns the blocks from the root to block, excluding the root
+func (f *ForkTree) Branch(block *ForkBlock) []*ForkBlock {
+	var branch []*ForkBlock
+	for ; block != f.root; block = block.Parent {
+		branch = append([]*ForkBlock{block}, branch...)
+	}
+
+	return branch
+}
+
+// Tips returns the blocks without children, in the order they were created
+func (f *ForkTree) Tips() []*ForkBlock {
+	var tips []*ForkBlock
+	for _, block := range f.blocks {
+		if len(block.Children) == 0 {
+			tips = append(tips, block)
+		}
+	}
+
+	return tips
+}
+
+// Orphaned returns the blocks that are not on the branch of head, in the order they were created
+func (f *ForkTree) Orphaned(head *ForkBlock) []*ForkBlock {
+	onBranch := map[*ForkBlock]struct{}{f.root: {}}
+	for _, block := range f.Branch(head) {
+		onBranch[block] = struct{}{}
+	}
+
+	var orphaned []*ForkBlock
+	for _, block := range f.blocks {
+		if _, ok := onBranch[block]; !ok {
+			orphaned = append(orphaned, block)
+		}
+	}
+
+	return orphaned
+}
+
+// OrphanedTransactions returns the transactions of blocks orphaned by head that are not on its branch.
+// A node switching to head returns these transactions to its mempool, unless they have become invalid.
+func (f *ForkTree) OrphanedTransactions(head *ForkBlock) []*protocol.Transaction {
+	included := make(map[string]struct{})
+	for _, block := range f.Branch(head) {
+		for _, tx := range block.Block.Transactions {
+			included[string(tx.Id)] = struct{}{}
+		}
+	}
+
+	var transactions []*protocol.Transaction
+	for _, block := range f.Orphaned(head) {
+		for _, tx := range block.Block.GetTransactions() {
+			if _, ok := included[string(tx.Id)]; !ok {
+				included[string(tx.Id)] = struct{}{}
+				transactions = append(transactions, tx)
+			}
+		}
+	}
+
+	return transactions
+}
+
+// RequireHead asserts the head block of the client is block
+func RequireHead(t *testing.T, client Client, block *ForkBlock) {
+	headInfo, err := GetHeadInfo(client)
+	NoError(t, err)
+
+	require.Equal(t, hex.EncodeToString(block.Id()), hex.EncodeToString(headInfo.HeadTopology.GetId()), "unexpected head at height %d, expected height %d", headInfo.HeadTopology.GetHeight(), block.Height())
+}
+
+// RequireForkHeads asserts the fork heads of the client are exactly blocks, in any order
+func RequireForkHeads(t *testing.T, client Client, blocks ...*ForkBlock) {
+	forkHeads, err := GetForkHeads(client)
+	NoError(t, err)
+
+	expected := make([]string, len(blocks))
+	for i, block := range blocks {
+		expected[i] = hex.EncodeToString(block.Id())
+	}
+
+	actual := make([]string, len(forkHeads.ForkHeads))
+	for i, head := range forkHeads.ForkHeads {
+		actual[i] = hex.EncodeToString(head.GetId())
+	}
+
+	require.ElementsMatch(t, expected, actual, "unexpected fork heads")
+}
+
+// RequirePending asserts every transaction is in the mempool of the client
+func RequirePending(t *testing.T, client Client, transactions ...*protocol.Transaction) {
+	pending, err := GetPendingTransactions(client, maxPendingTransactions)
+	NoError(t, err)
+
+	for _, tx := range transactions {
+		found := false
+		for _, pendingTrx := range pending {
+			if bytes.Equal(pendingTrx.GetTransaction().GetId(), tx.Id) {
+				found = true
+				break
+			}
+		}
+
+		require.True(t, found, "transaction 0x%s is not pending", hex.EncodeToString(tx.Id))
+	}
+}
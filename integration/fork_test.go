@@ -0,0 +1,101 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkTree(t *testing.T) {
+	client := fake.NewClient()
+
+	_, err := integration.CreateBlocksWith(client, 1)
+	require.NoError(t, err)
+
+	alice, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	bob, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	tree, err := integration.NewForkTree(client)
+	require.NoError(t, err)
+	root := tree.Root()
+	require.EqualValues(t, 1, root.Height())
+
+	aliceBuilder, err := integration.NewTransactionBuilder(client, integration.WithSigner(alice), integration.WithRcLimit(100))
+	require.NoError(t, err)
+
+	aliceTrxs := make([]*protocol.Transaction, 2)
+	for i := range aliceTrxs {
+		aliceTrxs[i], err = aliceBuilder.Build([]*protocol.Operation{})
+		require.NoError(t, err)
+
+		_, err = integration.SubmitTransaction(client, aliceTrxs[i])
+		require.NoError(t, err)
+	}
+
+	bobTrx, err := integration.CreateTransactionWith(client, []*protocol.Operation{}, integration.WithSigner(bob), integration.WithRcLimit(100))
+	require.NoError(t, err)
+
+	t.Logf("Extending the head")
+	a1, err := tree.Extend(root, []*protocol.Transaction{aliceTrxs[0]})
+	require.NoError(t, err)
+	integration.RequireHead(t, client, a1)
+	integration.RequireForkHeads(t, client, a1)
+	integration.RequirePending(t, client, aliceTrxs[1])
+
+	t.Logf("Forking at the same height keeps the head")
+	b1, err := tree.Extend(root, []*protocol.Transaction{})
+	require.NoError(t, err)
+	require.NotEqual(t, a1.Id(), b1.Id())
+	integration.RequireHead(t, client, a1)
+	integration.RequireForkHeads(t, client, a1, b1)
+	require.Equal(t, []*integration.ForkBlock{a1, b1}, tree.Tips())
+
+	t.Logf("Switching to a longer fork")
+	b2, err := tree.Extend(b1, []*protocol.Transaction{bobTrx})
+	require.NoError(t, err)
+	require.Equal(t, []*integration.ForkBlock{b1, b2}, tree.Branch(b2))
+	require.Equal(t, []*integration.ForkBlock{a1}, tree.Orphaned(b2))
+	require.Equal(t, []*protocol.Transaction{aliceTrxs[0]}, tree.OrphanedTransactions(b2))
+	integration.RequireHead(t, client, b2)
+	integration.RequireForkHeads(t, client, a1, b2)
+	integration.RequirePending(t, client, tree.OrphanedTransactions(b2)...)
+	integration.RequirePending(t, client, aliceTrxs[1])
+
+	nonce, err := integration.GetAccountNonce(client, alice.AddressBytes())
+	require.NoError(t, err)
+	require.EqualValues(t, 0, nonce)
+
+	t.Logf("Switching back")
+	a2, err := tree.Extend(a1, []*protocol.Transaction{aliceTrxs[1]})
+	require.NoError(t, err)
+	integration.RequireHead(t, client, b2)
+
+	a3, err := tree.ExtendN(a2, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, a3.Height())
+	integration.RequireHead(t, client, a3)
+	integration.RequireForkHeads(t, client, a3, b2)
+	require.Equal(t, []*protocol.Transaction{bobTrx}, tree.OrphanedTransactions(a3))
+	integration.RequirePending(t, client, bobTrx)
+
+	pending, err := integration.GetPendingTransactions(client, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	nonce, err = integration.GetAccountNonce(client, alice.AddressBytes())
+	require.NoError(t, err)
+	require.EqualValues(t, 2, nonce)
+
+	t.Logf("Rejecting a block on an unknown parent")
+	unknown := &integration.ForkBlock{Receipt: &protocol.BlockReceipt{Id: []byte{0x12, 0x20}, Height: 4}}
+	_, err = tree.Extend(unknown, []*protocol.Transaction{})
+	require.ErrorIs(t, err, fake.ErrInvalidBlock)
+	require.Len(t, tree.Tips(), 2)
+}
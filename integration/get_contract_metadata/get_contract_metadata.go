@@ -0,0 +1,4 @@
+// Package get_contract_metadata wraps the get_contract_metadata contract
+package get_contract_metadata
+
+//go:generate go run ../../cmd/koinos-abigen -abi get_contract_metadata.abi.json -out get_contract_metadata_abi.go
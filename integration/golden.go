@@ -0,0 +1,31 @@
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// RequireGolden requires the JSON encoding of value to match the golden file at path. With update,
+// the golden file is rewritten from value instead.
+func RequireGolden(t *testing.T, path string, value interface{}, update bool) {
+	t.Helper()
+
+	actual, err := json.MarshalIndent(value, "", "  ")
+	NoError(t, err)
+	actual = append(actual, '\n')
+
+	if update {
+		NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		NoError(t, os.WriteFile(path, actual, 0o644))
+		t.Logf("Updated golden file %s", path)
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "reading golden file %s, run with -update to create it", path)
+	require.JSONEq(t, string(expected), string(actual), "golden file %s is out of date, run with -update to rewrite it", path)
+}
@@ -0,0 +1,25 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "values.json")
+	values := []integration.MarketValues{{DiskSupply: 1, DiskCost: 2}}
+
+	t.Logf("Writing the golden file with update")
+	integration.RequireGolden(t, path, values, true)
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `"disk_supply": 1`)
+
+	t.Logf("Matching the golden file")
+	integration.RequireGolden(t, path, values, false)
+}
@@ -1,6 +1,7 @@
 package governance
 
 import (
+	"context"
 	"fmt"
 	"koinos-integration-tests/integration"
 	"koinos-integration-tests/integration/token"
@@ -9,31 +10,37 @@ import (
 	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/governance"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
 	util "github.com/koinos/koinos-util-golang/v2"
-	"google.golang.org/protobuf/proto"
 )
 
-const (
-	submitProposalEntry       uint32 = 0xe74b785c
-	getProposalByIdEntry      uint32 = 0xc66013ad
-	getProposalsEntry         uint32 = 0xd44caa11
-	getProposalsByStatusEntry uint32 = 0x66206f76
-)
+//go:generate go run ../../cmd/koinos-abigen -abi governance.abi.json -out governance_abi.go
 
 // A wrapper around the governance contract
 type Governance struct {
-	key    *util.KoinosKey
-	client integration.Client
+	key      *util.KoinosKey
+	client   integration.Client
+	contract *GovernanceContract
 }
 
 // GetGoverance returns the goverance contract object
 func GetGovernance(client integration.Client) *Governance {
 	governanceKey, _ := integration.GetKey(integration.Governance)
 
-	return &Governance{key: governanceKey, client: client}
+	return &Governance{key: governanceKey, client: client, contract: NewGovernanceContract(client, governanceKey.AddressBytes())}
+}
+
+// WithContext returns a copy of the governance contract whose calls are made within ctx
+func (g *Governance) WithContext(ctx context.Context) *Governance {
+	return g.withClient(integration.WithContext(g.client, ctx))
+}
+
+func (g *Governance) withClient(client integration.Client) *Governance {
+	return &Governance{key: g.key, client: client, contract: NewGovernanceContract(client, g.contract.Address)}
 }
 
 // SubmitProposal to the goverance contract
 func (g *Governance) SubmitProposal(t *testing.T, payer *util.KoinosKey, mroot []byte, ops []*protocol.Operation, fee uint64) (*protocol.BlockReceipt, error) {
+	g = g.withClient(integration.ForTest(t, g.client))
+
 	koin := token.GetKoinToken(g.client)
 
 	err := koin.Approve(payer, g.key.AddressBytes(), fee)
@@ -41,53 +48,18 @@ func (g *Governance) SubmitProposal(t *testing.T, payer *util.KoinosKey, mroot [
 		return nil, err
 	}
 
-	submitProposalArgs := &governance.SubmitProposalArguments{
+	return g.contract.SubmitProposal(&governance.SubmitProposalArguments{
 		Operations:          ops,
 		OperationMerkleRoot: mroot,
 		Fee:                 fee,
-	}
-
-	args, err := proto.Marshal(submitProposalArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	op := &protocol.Operation{
-		Op: &protocol.Operation_CallContract{
-			CallContract: &protocol.CallContractOperation{
-				ContractId: g.key.AddressBytes(),
-				EntryPoint: submitProposalEntry,
-				Args:       args,
-			},
-		},
-	}
-
-	transaction, err := integration.CreateTransaction(g.client, []*protocol.Operation{op}, payer)
-	if err != nil {
-		return nil, err
-	}
-
-	return integration.CreateBlock(g.client, []*protocol.Transaction{transaction})
+	}, payer)
 }
 
 // GetProposalById
 func (g *Governance) GetProposalById(id []byte) (*governance.ProposalRecord, error) {
-	getProposalArgs := &governance.GetProposalByIdArguments{
+	proposal, err := g.contract.GetProposalById(&governance.GetProposalByIdArguments{
 		ProposalId: id,
-	}
-
-	args, err := proto.Marshal(getProposalArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := integration.ReadContract(g.client, args, g.key.AddressBytes(), getProposalByIdEntry)
-	if err != nil {
-		return nil, err
-	}
-
-	proposal := &governance.GetProposalByIdResult{}
-	err = proto.Unmarshal(resp.GetResult(), proposal)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -117,23 +89,10 @@ func (g *Governance) GetProposals(vars ...interface{}) ([]*governance.ProposalRe
 		}
 	}
 
-	getProposalsArgs := &governance.GetProposalsArguments{
+	proposals, err := g.contract.GetProposals(&governance.GetProposalsArguments{
 		StartProposal: start,
 		Limit:         limit,
-	}
-
-	args, err := proto.Marshal(getProposalsArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := integration.ReadContract(g.client, args, g.key.AddressBytes(), getProposalsEntry)
-	if err != nil {
-		return nil, err
-	}
-
-	proposals := &governance.GetProposalsResult{}
-	err = proto.Unmarshal(resp.GetResult(), proposals)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -163,24 +122,11 @@ func (g *Governance) GetProposalsByStatus(status governance.ProposalStatus, vars
 		}
 	}
 
-	getProposalsArgs := &governance.GetProposalsByStatusArguments{
+	proposals, err := g.contract.GetProposalsByStatus(&governance.GetProposalsByStatusArguments{
 		StartProposal: start,
 		Limit:         limit,
 		Status:        status,
-	}
-
-	args, err := proto.Marshal(getProposalsArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := integration.ReadContract(g.client, args, g.key.AddressBytes(), getProposalsByStatusEntry)
-	if err != nil {
-		return nil, err
-	}
-
-	proposals := &governance.GetProposalsByStatusResult{}
-	err = proto.Unmarshal(resp.GetResult(), proposals)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1,26 +1,26 @@
 package integration
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/btcsuite/btcd/btcec"
-	"github.com/btcsuite/btcutil/base58"
 	koinosmq "github.com/koinos/koinos-mq-golang"
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/canonical"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
 	name_service "github.com/koinos/koinos-proto-golang/v2/koinos/contracts/name-service"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/token"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
 	block_store_rpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
 	chainrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
@@ -30,7 +30,6 @@ import (
 	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/p2p"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/transaction_store"
 	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/multiformats/go-multihash"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -39,6 +38,8 @@ import (
 	"google.golang.org/protobuf/types/dynamicpb"
 )
 
+//go:generate go run ../cmd/koinos-abigen -entries -package integration -out entries_abi.go name_service/name_service.abi.json get_contract_metadata/get_contract_metadata.abi.json token/koin.abi.json resources/resources.abi.json
+
 const (
 	Genesis int = iota
 	Governance
@@ -77,11 +78,11 @@ const (
 	GetChainIDCall             = "chain.get_chain_id"
 	GetContractMetaCall        = "contract_meta_store.get_contract_meta"
 	GetHeadInfoCall            = "chain.get_head_info"
+	GetForkHeadsCall           = "chain.get_fork_heads"
 	SubmitBlockCall            = "chain.submit_block"
 	GetPendingTransactionsCall = "mempool.get_pending_transactions"
+	GetPendingNonceCall        = "mempool.get_pending_nonce"
 	GetBlocksByHeightCall      = "block_store.get_blocks_by_height"
-
-	defaultTimeout = time.Second
 )
 
 // Client is an interface for different types of message clients
@@ -90,6 +91,8 @@ type Client interface {
 }
 
 func InitNameService(t *testing.T, client Client) {
+	client = ForTest(t, client)
+
 	nameServiceKey, err := GetKey(NameService)
 	NoError(t, err)
 
@@ -98,15 +101,17 @@ func InitNameService(t *testing.T, client Client) {
 	NoError(t, err)
 
 	t.Logf("Overriding get_contract_name")
-	err = SetSystemCallOverride(client, nameServiceKey, uint32(0xe5070a16), uint32(chain.SystemCallId_get_contract_name))
+	err = SetSystemCallOverride(client, nameServiceKey, nameServiceGetNameEntry, uint32(chain.SystemCallId_get_contract_name))
 	NoError(t, err)
 
 	t.Logf("Overriding get_contract_address")
-	err = SetSystemCallOverride(client, nameServiceKey, uint32(0xa61ae5e8), uint32(chain.SystemCallId_get_contract_address))
+	err = SetSystemCallOverride(client, nameServiceKey, nameServiceGetAddressEntry, uint32(chain.SystemCallId_get_contract_address))
 	NoError(t, err)
 }
 
 func InitGetContractMetadata(t *testing.T, client Client) {
+	client = ForTest(t, client)
+
 	getContractMetadataKey, err := GetKey(GetContractMetadata)
 	NoError(t, err)
 
@@ -115,11 +120,13 @@ func InitGetContractMetadata(t *testing.T, client Client) {
 	NoError(t, err)
 
 	t.Logf("Overriding get_contract_metadata")
-	err = SetSystemCallOverride(client, getContractMetadataKey, uint32(0x784faa08), uint32(chain.SystemCallId_get_contract_metadata))
+	err = SetSystemCallOverride(client, getContractMetadataKey, getContractMetadataGetContractMetadataEntry, uint32(chain.SystemCallId_get_contract_metadata))
 	NoError(t, err)
 }
 
 func InitResources(t *testing.T, client Client) {
+	client = ForTest(t, client)
+
 	koinKey, err := GetKey(Koin)
 	NoError(t, err)
 
@@ -143,7 +150,7 @@ func InitResources(t *testing.T, client Client) {
 						Target: &protocol.SystemCallTarget_SystemCallBundle{
 							SystemCallBundle: &protocol.ContractCallBundle{
 								ContractId: koinKey.AddressBytes(),
-								EntryPoint: uint32(0x2d464aab),
+								EntryPoint: koinGetAccountRcEntry,
 							},
 						},
 					},
@@ -158,7 +165,7 @@ func InitResources(t *testing.T, client Client) {
 						Target: &protocol.SystemCallTarget_SystemCallBundle{
 							SystemCallBundle: &protocol.ContractCallBundle{
 								ContractId: koinKey.AddressBytes(),
-								EntryPoint: uint32(0x80e3f5c9),
+								EntryPoint: koinConsumeAccountRcEntry,
 							},
 						},
 					},
@@ -173,7 +180,7 @@ func InitResources(t *testing.T, client Client) {
 						Target: &protocol.SystemCallTarget_SystemCallBundle{
 							SystemCallBundle: &protocol.ContractCallBundle{
 								ContractId: resourcesKey.AddressBytes(),
-								EntryPoint: uint32(0x427a0394),
+								EntryPoint: resourcesGetResourceLimitsEntry,
 							},
 						},
 					},
@@ -188,7 +195,7 @@ func InitResources(t *testing.T, client Client) {
 						Target: &protocol.SystemCallTarget_SystemCallBundle{
 							SystemCallBundle: &protocol.ContractCallBundle{
 								ContractId: resourcesKey.AddressBytes(),
-								EntryPoint: uint32(0x9850b1fd),
+								EntryPoint: resourcesConsumeBlockResourcesEntry,
 							},
 						},
 					},
@@ -197,10 +204,10 @@ func InitResources(t *testing.T, client Client) {
 		},
 	}
 
-	tx, err := CreateTransaction(client, overrides, genesisKey)
+	tx, err := CreateTransactionWith(client, overrides, WithSigner(genesisKey))
 	NoError(t, err)
 
-	_, err = CreateBlock(client, []*protocol.Transaction{tx}, genesisKey)
+	_, err = CreateBlockWith(client, []*protocol.Transaction{tx}, WithSigner(genesisKey))
 	NoError(t, err)
 }
 
@@ -210,7 +217,7 @@ func GetHeadInfo(client Client) (*chainrpc.GetHeadInfoResponse, error) {
 
 	headInfo := &chainrpc.GetHeadInfoResponse{}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultTimeout)
 	defer cancel()
 
 	err := client.Call(ctx, GetHeadInfoCall, &params, headInfo)
@@ -221,6 +228,23 @@ func GetHeadInfo(client Client) (*chainrpc.GetHeadInfoResponse, error) {
 	return headInfo, nil
 }
 
+// GetForkHeads gets the last irreversible block and the head of every fork
+func GetForkHeads(client Client) (*chainrpc.GetForkHeadsResponse, error) {
+	params := chainrpc.GetForkHeadsRequest{}
+
+	forkHeads := &chainrpc.GetForkHeadsResponse{}
+
+	ctx, cancel := callContext(client, DefaultTimeout)
+	defer cancel()
+
+	err := client.Call(ctx, GetForkHeadsCall, &params, forkHeads)
+	if err != nil {
+		return nil, err
+	}
+
+	return forkHeads, nil
+}
+
 // GetBlocksByHeight gets blocks for the given height
 func GetBlocksByHeight(client Client, headBlockId []byte, ancestorStartHeight uint64, numBlocks uint32, returnBlock bool, returnReceipt bool) (*block_store_rpc.GetBlocksByHeightResponse, error) {
 	params := block_store_rpc.GetBlocksByHeightRequest{
@@ -233,7 +257,7 @@ func GetBlocksByHeight(client Client, headBlockId []byte, ancestorStartHeight ui
 
 	blocksByHeight := &block_store_rpc.GetBlocksByHeightResponse{}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultTimeout)
 	defer cancel()
 
 	err := client.Call(ctx, GetBlocksByHeightCall, &params, blocksByHeight)
@@ -250,7 +274,7 @@ func GetPendingTransactions(client Client, limit uint64) ([]*mempool.PendingTran
 
 	pendingTrans := &mempoolrpc.GetPendingTransactionsResponse{}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultTimeout)
 	defer cancel()
 
 	err := client.Call(ctx, GetPendingTransactionsCall, &params, pendingTrans)
@@ -261,6 +285,23 @@ func GetPendingTransactions(client Client, limit uint64) ([]*mempool.PendingTran
 	return pendingTrans.PendingTransactions, nil
 }
 
+// GetPendingNonce gets the nonce of a given account including transactions pending in the mempool
+func GetPendingNonce(client Client, address []byte) (uint64, error) {
+	params := mempoolrpc.GetPendingNonceRequest{Payee: address}
+
+	var mResp mempoolrpc.GetPendingNonceResponse
+
+	ctx, cancel := callContext(client, DefaultTimeout)
+	defer cancel()
+
+	err := client.Call(ctx, GetPendingNonceCall, &params, &mResp)
+	if err != nil {
+		return 0, err
+	}
+
+	return util.NonceBytesToUInt64(mResp.Nonce)
+}
+
 // GetAccountNonce gets the nonce of a given account
 func GetAccountNonce(client Client, address []byte) (uint64, error) {
 	// Build the contract request
@@ -271,7 +312,7 @@ func GetAccountNonce(client Client, address []byte) (uint64, error) {
 	// Make the rpc call
 	var cResp chainrpc.GetAccountNonceResponse
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultTimeout)
 	defer cancel()
 
 	err := client.Call(ctx, GetAccountNonceCall, &params, &cResp)
@@ -295,7 +336,7 @@ func ReadContract(client Client, args []byte, contractID []byte, entryPoint uint
 	// Make the rpc call
 	var cResp chainrpc.ReadContractResponse
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultTimeout)
 	defer cancel()
 
 	err := client.Call(ctx, ReadContractCall, &params, &cResp)
@@ -341,7 +382,7 @@ func GetAccountRc(client Client, address []byte) (uint64, error) {
 	// Make the rpc call
 	var cResp chainrpc.GetAccountRcResponse
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultTimeout)
 	defer cancel()
 
 	err := client.Call(ctx, GetAccountRcCall, &params, &cResp)
@@ -360,7 +401,7 @@ func GetChainID(client Client) ([]byte, error) {
 	// Make the rpc call
 	var cResp chainrpc.GetChainIdResponse
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultTimeout)
 	defer cancel()
 
 	err := client.Call(ctx, GetChainIDCall, &params, &cResp)
@@ -373,6 +414,7 @@ func GetChainID(client Client) ([]byte, error) {
 
 type MQClient struct {
 	client *koinosmq.Client
+	url    string
 }
 
 func NewKoinosMQClient(url string) *MQClient {
@@ -380,6 +422,7 @@ func NewKoinosMQClient(url string) *MQClient {
 	c.Start(context.Background())
 	return &MQClient{
 		client: c,
+		url:    url,
 	}
 }
 
@@ -450,8 +493,13 @@ func translateResponse(service string, resBytes []byte, response proto.Message)
 		return err
 	}
 
+	fields := wrapped.ProtoReflect().Descriptor().Fields()
+	if errField := fields.ByName("error"); errField != nil && wrapped.ProtoReflect().Has(errField) {
+		return chainErrorFromStatus(wrapped.ProtoReflect().Get(errField).Message().Interface().(*rpc.ErrorStatus))
+	}
+
 	name := response.ProtoReflect().Descriptor().Name()
-	fieldd := wrapped.ProtoReflect().Descriptor().Fields().ByName(name[0 : len(name)-9])
+	fieldd := fields.ByName(name[0 : len(name)-9])
 	proto.Merge(response, wrapped.ProtoReflect().Get(fieldd).Message().Interface())
 
 	return nil
@@ -473,20 +521,17 @@ func (mq *MQClient) Call(ctx context.Context, method string, params proto.Messag
 		return err
 	}
 
-	err = translateResponse(s[0], resBytes, returnType)
-	if err != nil {
-		return err
-	}
-
-	return err
+	return decodeChainError(translateResponse(s[0], resBytes, returnType))
 }
 
+// GetKey returns the key of a system role, or of a role added with RegisterRole, from DefaultKeyring
 func GetKey(keyType int) (*util.KoinosKey, error) {
-	wif, exists := wifMap[keyType]
-	if exists {
-		return KeyFromWIF(wif)
+	name, exists := RoleName(keyType)
+	if !exists {
+		return nil, errors.New("invalid key type")
 	}
-	return nil, errors.New("invalid key type")
+
+	return NamedKey(name)
 }
 
 type eventList []*protocol.EventData
@@ -508,54 +553,82 @@ func (e eventList) Swap(i, j int) {
 //
 //	key *util.KoinosKey - Key to produce the block with
 //	mod func(b *protocol.Block) error - Modification callback function
+//	opt BlockOption - Any block option
+//
+// Deprecated: Use CreateBlockWith
 func CreateBlock(client Client, transactions []*protocol.Transaction, vars ...interface{}) (*protocol.BlockReceipt, error) {
-	var key *util.KoinosKey
-	var mod func(b *protocol.Block) error
-
-	if len(vars) > 0 {
-		for _, v := range vars {
-			switch t := v.(type) {
-			case *util.KoinosKey:
-				key = t
-			case func(b *protocol.Block) error:
-				mod = t
-			default:
-				return nil, fmt.Errorf("unexpected argument")
-			}
-		}
-	} else {
+	opts, err := blockOptionsFromVars(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateBlockWith(client, transactions, opts...)
+}
+
+// CreateBlockWith creates and submits a block from a list of transactions.
+// When the client is bound to a test with ForTest, the invariants of the test are checked after
+// the block is submitted. Blocks created through unbound clients are not checked.
+func CreateBlockWith(client Client, transactions []*protocol.Transaction, opts ...BlockOption) (*protocol.BlockReceipt, error) {
+	options := &blockOptions{}
+	for _, opt := range opts {
+		opt.applyBlock(options)
+	}
+
+	key := options.signer
+	if key == nil {
 		genesisKey, err := GetKey(Genesis)
 		if err != nil {
 			return nil, err
 		}
 
 		key = genesisKey
-		mod = nil
 	}
 
 	block := &protocol.Block{}
 	block.Header = &protocol.BlockHeader{}
 
-	headInfo, err := GetHeadInfo(client)
+	// The head before the block is only needed with a parent to check invariants
+	t := invariantTest(client)
+	var previous *koinos.BlockTopology
+
+	if options.parent == nil || t != nil {
+		headInfo, err := GetHeadInfo(client)
+		if err != nil {
+			return nil, err
+		}
+
+		previous = headInfo.HeadTopology
+		block.Header.Previous = headInfo.HeadTopology.GetId()
+		block.Header.Height = headInfo.HeadTopology.GetHeight() + 1
+		block.Header.PreviousStateMerkleRoot = headInfo.GetHeadStateMerkleRoot()
+	}
+
+	if options.parent != nil {
+		block.Header.Previous = options.parent.GetId()
+		block.Header.Height = options.parent.GetHeight() + 1
+		block.Header.PreviousStateMerkleRoot = options.parent.GetStateMerkleRoot()
+	}
+
+	if options.timestamp != nil {
+		block.Header.Timestamp = *options.timestamp
+	} else {
+		block.Header.Timestamp = uint64(time.Now().UnixMilli())
+	}
 
-	block.Header.Previous = headInfo.HeadTopology.GetId()
-	block.Header.Height = headInfo.HeadTopology.GetHeight() + 1
-	block.Header.Timestamp = uint64(time.Now().UnixMilli())
-	block.Header.PreviousStateMerkleRoot = headInfo.GetHeadStateMerkleRoot()
 	block.Header.Signer = key.AddressBytes()
 
 	block.Transactions = append(block.Transactions, transactions...)
 
-	if mod != nil {
-		if err = mod(block); err != nil {
+	for _, mod := range options.mutators {
+		if err := mod(block); err != nil {
 			return nil, err
 		}
 	}
 
 	// Get transaction multihashes
-	transactionHashes := make([][]byte, len(transactions)*2)
+	transactionHashes := make([][]byte, len(block.Transactions)*2)
 	hasher := sha256.New()
-	for i, tx := range transactions {
+	for i, tx := range block.Transactions {
 		transactionHashes[i*2] = tx.GetId()
 
 		hasher.Reset()
@@ -571,6 +644,7 @@ func CreateBlock(client Client, transactions []*protocol.Transaction, vars ...in
 
 	// Find merkle root
 	var merkleRoot []byte
+	var err error
 	if len(transactionHashes) > 0 {
 		merkleRoot, err = util.CalculateMerkleRoot(transactionHashes)
 		if err != nil {
@@ -619,7 +693,7 @@ func CreateBlock(client Client, transactions []*protocol.Transaction, vars ...in
 
 	var submitBlockResp chainrpc.SubmitBlockResponse
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultBlockTimeout)
 	defer cancel()
 
 	err = client.Call(ctx, SubmitBlockCall, &chainrpc.SubmitBlockRequest{Block: block}, &submitBlockResp)
@@ -627,6 +701,10 @@ func CreateBlock(client Client, transactions []*protocol.Transaction, vars ...in
 		return nil, err
 	}
 
+	if t != nil {
+		checkInvariants(t, &BlockCheck{Client: client, Block: block, Receipt: submitBlockResp.Receipt, Previous: previous})
+	}
+
 	return submitBlockResp.Receipt, nil
 }
 
@@ -635,11 +713,24 @@ func CreateBlock(client Client, transactions []*protocol.Transaction, vars ...in
 //
 //	key *util.KoinosKey - Key to produce the block with
 //	mod func(b *protocol.Block) error - Modification callback function, called on each block
+//	opt BlockOption - Any block option
+//
+// Deprecated: Use CreateBlocksWith
 func CreateBlocks(client Client, n int, vars ...interface{}) ([]*protocol.BlockReceipt, error) {
+	opts, err := blockOptionsFromVars(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateBlocksWith(client, n, opts...)
+}
+
+// CreateBlocksWith creates 'n' empty blocks, applying the options to each block
+func CreateBlocksWith(client Client, n int, opts ...BlockOption) ([]*protocol.BlockReceipt, error) {
 	receipts := make([]*protocol.BlockReceipt, 0)
 
 	for i := 0; i < n; i++ {
-		receipt, err := CreateBlock(client, []*protocol.Transaction{}, vars...)
+		receipt, err := CreateBlockWith(client, []*protocol.Transaction{}, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -653,45 +744,68 @@ func CreateBlocks(client Client, n int, vars ...interface{}) ([]*protocol.BlockR
 // CreateTransaction creates a transaction from a list of operations
 // Variadic arguments can be the following:
 //
-//	mod func(b *protocol.Block) error - Modification callback function, called on each block
+//	mod func(t *protocol.Transaction) error - Modification callback function
 //	*util.KoinosKey - Key to sign the transaction with, the first key is used to retreive the nonce
+//	opt TransactionOption - Any transaction option
+//
+// Deprecated: Use CreateTransactionWith
 func CreateTransaction(client Client, ops []*protocol.Operation, vars ...interface{}) (*protocol.Transaction, error) {
-	var mod func(b *protocol.Transaction) error = nil
-	keys := make([]*util.KoinosKey, 0)
-
-	if len(vars) > 0 {
-		for _, v := range vars {
-			switch t := v.(type) {
-			case *util.KoinosKey:
-				keys = append(keys, t)
-			case func(b *protocol.Transaction) error:
-				mod = t
-			default:
-				return nil, fmt.Errorf("unexpected argument")
-			}
-		}
+	opts, err := transactionOptionsFromVars(vars)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(keys) == 0 {
+	return CreateTransactionWith(client, ops, opts...)
+}
+
+// CreateTransactionWith creates a signed transaction from a list of operations. At least one
+// signer is required.
+func CreateTransactionWith(client Client, ops []*protocol.Operation, opts ...TransactionOption) (*protocol.Transaction, error) {
+	options := newTransactionOptions(opts)
+	if len(options.signers) == 0 {
 		return nil, fmt.Errorf("expected at least one key")
 	}
 
-	// Cache the public address
-	address := keys[0].AddressBytes()
+	return createTransaction(client, ops, options)
+}
 
-	// Fetch the account's nonce
-	nonce, err := GetAccountNonce(client, address)
-	if err != nil {
-		return nil, err
+// createTransaction creates a transaction, signed by any signers of the options
+func createTransaction(client Client, ops []*protocol.Operation, options *transactionOptions) (*protocol.Transaction, error) {
+	var nonce uint64
+	if options.nonce != nil {
+		nonce = *options.nonce
+	} else {
+		// Fetch the account's nonce
+		accountNonce, err := GetAccountNonce(client, options.nonceAccount())
+		if err != nil {
+			return nil, err
+		}
+
+		nonce = accountNonce + 1
 	}
 
-	// Convert none+1 to bytes
-	nonceBytes, err := util.UInt64ToNonceBytes(nonce + 1)
+	chainID, err := GetChainID(client)
 	if err != nil {
 		return nil, err
 	}
 
-	rcLimit, err := GetAccountRc(client, address)
+	var rcLimit uint64
+	if options.rcLimit != nil {
+		rcLimit = *options.rcLimit
+	} else {
+		rcLimit, err = options.rcPolicy.rcLimit(client, ops, options, chainID, nonce)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buildTransaction(ops, options, chainID, nonce, rcLimit)
+}
+
+// buildTransaction creates and signs a transaction without querying the chain. Without signers
+// the transaction is left unsigned.
+func buildTransaction(ops []*protocol.Operation, options *transactionOptions, chainID []byte, nonce uint64, rcLimit uint64) (*protocol.Transaction, error) {
+	nonceBytes, err := util.UInt64ToNonceBytes(nonce)
 	if err != nil {
 		return nil, err
 	}
@@ -711,38 +825,30 @@ func CreateTransaction(client Client, ops []*protocol.Operation, vars ...interfa
 		return nil, err
 	}
 
-	chainID, err := GetChainID(client)
-	if err != nil {
-		return nil, err
+	// Create the transaction
+	address := options.nonceAccount()
+	payer := options.payerAddress()
+
+	header := &protocol.TransactionHeader{ChainId: chainID, RcLimit: rcLimit, Nonce: nonceBytes, OperationMerkleRoot: merkleRoot, Payer: payer}
+	if !bytes.Equal(payer, address) {
+		header.Payee = address
 	}
 
-	// Create the transaction
-	header := protocol.TransactionHeader{ChainId: chainID, RcLimit: rcLimit, Nonce: nonceBytes, OperationMerkleRoot: merkleRoot, Payer: address}
-	transaction := &protocol.Transaction{Header: &header, Operations: ops}
+	transaction := &protocol.Transaction{Header: header, Operations: ops}
 
-	if mod != nil {
+	for _, mod := range options.mutators {
 		if err = mod(transaction); err != nil {
 			return nil, err
 		}
 	}
 
-	// Calculate the transaction ID
-	headerBytes, err := canonical.Marshal(&header)
-	if err != nil {
-		return nil, err
-	}
-
-	sha256Hasher := sha256.New()
-	sha256Hasher.Write(headerBytes)
-	tid, err := multihash.Encode(sha256Hasher.Sum(nil), multihash.SHA2_256)
+	transaction.Id, err = transactionID(transaction.Header)
 	if err != nil {
 		return nil, err
 	}
 
-	transaction.Id = tid
-
 	// Sign the transaction
-	for _, key := range keys {
+	for _, key := range options.signers {
 		if err := util.SignTransaction(key.PrivateBytes(), transaction); err != nil {
 			return nil, err
 		}
@@ -751,16 +857,33 @@ func CreateTransaction(client Client, ops []*protocol.Operation, vars ...interfa
 	return transaction, nil
 }
 
+// transactionID calculates the id of a transaction from its header
+func transactionID(header *protocol.TransactionHeader) ([]byte, error) {
+	headerBytes, err := canonical.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	sha256Hasher := sha256.New()
+	sha256Hasher.Write(headerBytes)
+	return multihash.Encode(sha256Hasher.Sum(nil), multihash.SHA2_256)
+}
+
 func SubmitTransaction(client Client, transaction *protocol.Transaction) (*protocol.TransactionReceipt, error) {
+	return submitTransaction(client, transaction, true)
+}
 
+// submitTransaction submits a transaction. Without broadcast, the transaction is applied as a trial
+// and does not reach the mempool.
+func submitTransaction(client Client, transaction *protocol.Transaction, broadcast bool) (*protocol.TransactionReceipt, error) {
 	request := &chainrpc.SubmitTransactionRequest{
 		Transaction: transaction,
-		Broadcast:   true,
+		Broadcast:   broadcast,
 	}
 
 	response := chainrpc.SubmitTransactionResponse{}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := callContext(client, DefaultTimeout)
 	defer cancel()
 
 	err := client.Call(ctx, SubmitTransactionCall, request, &response)
@@ -771,11 +894,14 @@ func SubmitTransaction(client Client, transaction *protocol.Transaction) (*proto
 	return response.Receipt, nil
 }
 
-// AwaitChain blocks until the chain rpc is responding
+// AwaitChain blocks until the chain rpc is responding, failing the test when its deadline approaches
 func AwaitChain(t *testing.T, client Client) {
 	var waitDuration int64 = 1
 	const maxRetry int64 = 5
 
+	client = ForTest(t, client)
+	ctx := clientContext(client)
+
 	for {
 		_, err := GetHeadInfo(client)
 		if err == nil {
@@ -783,7 +909,12 @@ func AwaitChain(t *testing.T, client Client) {
 		}
 
 		t.Logf("Waiting %s for chain to be ready...\n", time.Duration(waitDuration)*time.Second)
-		time.Sleep(time.Duration(waitDuration) * time.Second)
+		select {
+		case <-time.After(time.Duration(waitDuration) * time.Second):
+		case <-ctx.Done():
+			t.Fatalf("Chain was not ready: %s", err)
+		}
+
 		if waitDuration*2 > maxRetry {
 			waitDuration = maxRetry
 		} else {
@@ -847,12 +978,12 @@ func SetSystemCallOverride(client Client, key *util.KoinosKey, entryPoint uint32
 		return err
 	}
 
-	transaction, err := CreateTransaction(client, []*protocol.Operation{op}, genesisKey)
+	transaction, err := CreateTransactionWith(client, []*protocol.Operation{op}, WithSigner(genesisKey))
 	if err != nil {
 		return err
 	}
 
-	_, err = CreateBlock(client, []*protocol.Transaction{transaction}, genesisKey)
+	_, err = CreateBlockWith(client, []*protocol.Transaction{transaction}, WithSigner(genesisKey))
 	return err
 }
 
@@ -873,7 +1004,7 @@ func UploadContractTransaction(client Client, file string, key *util.KoinosKey)
 		},
 	}
 
-	transaction, err := CreateTransaction(client, []*protocol.Operation{uploadOperation}, key)
+	transaction, err := CreateTransactionWith(client, []*protocol.Operation{uploadOperation}, WithSigner(key))
 	if err != nil {
 		return nil, err
 	}
@@ -911,12 +1042,12 @@ func UploadContract(client Client, file string, key *util.KoinosKey, mods ...fun
 		}
 	}
 
-	transaction1, err := CreateTransaction(client, []*protocol.Operation{uploadOperation}, key)
+	transaction1, err := CreateTransactionWith(client, []*protocol.Operation{uploadOperation}, WithSigner(key))
 	if err != nil {
 		return err
 	}
 
-	_, err = CreateBlock(client, []*protocol.Transaction{transaction1})
+	_, err = CreateBlockWith(client, []*protocol.Transaction{transaction1})
 	return err
 }
 
@@ -950,7 +1081,7 @@ func UploadSystemContract(client Client, file string, key *util.KoinosKey, name
 		}
 	}
 
-	transaction1, err := CreateTransaction(client, []*protocol.Operation{uploadOperation}, key)
+	transaction1, err := CreateTransactionWith(client, []*protocol.Operation{uploadOperation}, WithSigner(key))
 	if err != nil {
 		return nil, err
 	}
@@ -991,18 +1122,18 @@ func UploadSystemContract(client Client, file string, key *util.KoinosKey, name
 		Op: &protocol.Operation_CallContract{
 			CallContract: &protocol.CallContractOperation{
 				ContractId: nsKey.AddressBytes(),
-				EntryPoint: uint32(0xe248c73a),
+				EntryPoint: nameServiceSetRecordEntry,
 				Args:       args,
 			},
 		},
 	}
 
-	transaction2, err := CreateTransaction(client, []*protocol.Operation{setSystemContractOperation, setNameOperation}, genesisKey)
+	transaction2, err := CreateTransactionWith(client, []*protocol.Operation{setSystemContractOperation, setNameOperation}, WithSigner(genesisKey))
 	if err != nil {
 		return nil, err
 	}
 
-	return CreateBlock(client, []*protocol.Transaction{transaction1, transaction2})
+	return CreateBlockWith(client, []*protocol.Transaction{transaction1, transaction2})
 }
 
 // EventsFromBlockReceipt parses a block receipt, returning all events contained within the receipt
@@ -1020,87 +1151,6 @@ func EventsFromBlockReceipt(blockReceipt *protocol.BlockReceipt) []*protocol.Eve
 	return events
 }
 
-// LogBlockReceipt logs log messages contained within a block receipt
-func LogBlockReceipt(t *testing.T, blockReceipt *protocol.BlockReceipt) {
-	blockID := base58.Encode(blockReceipt.Id)
-	t.Logf("Block: " + blockID)
-	t.Logf("Compute: " + strconv.FormatUint(blockReceipt.ComputeBandwidthUsed, 10))
-	t.Logf("Disk: " + strconv.FormatUint(blockReceipt.DiskStorageUsed, 10))
-	t.Logf("Network: " + strconv.FormatUint(blockReceipt.NetworkBandwidthUsed, 10))
-
-	if len(blockReceipt.Logs) > 0 {
-		t.Logf(" * Logs")
-		for _, log := range blockReceipt.Logs {
-			t.Logf("  - " + log)
-		}
-	}
-
-	if len(blockReceipt.Events) > 0 {
-		t.Logf(" * Events")
-		for _, event := range blockReceipt.Events {
-			bytes := base64.StdEncoding.EncodeToString(event.Data)
-			t.Logf("  - " + event.Name + ": " + bytes)
-		}
-	}
-
-	for _, txReceipt := range blockReceipt.TransactionReceipts {
-		transactionID := base58.Encode(txReceipt.Id)
-		if txReceipt.Reverted {
-			t.Logf(" > Transaction: " + transactionID + " (reverted)")
-		} else {
-			t.Logf(" > Transaction: " + transactionID)
-		}
-
-		t.Logf("  * Compute: " + strconv.FormatUint(txReceipt.ComputeBandwidthUsed, 10))
-		t.Logf("  * Disk: " + strconv.FormatUint(txReceipt.DiskStorageUsed, 10))
-		t.Logf("  * Network: " + strconv.FormatUint(txReceipt.NetworkBandwidthUsed, 10))
-
-		if len(txReceipt.Logs) > 0 {
-			t.Logf("  * Logs")
-			for _, log := range txReceipt.Logs {
-				t.Logf("   - " + log)
-			}
-		}
-
-		if len(txReceipt.Events) > 0 {
-			t.Logf("  * Events")
-			for _, event := range txReceipt.Events {
-				bytes := base64.StdEncoding.EncodeToString(event.Data)
-				t.Logf("   - " + event.Name + ": " + bytes)
-			}
-		}
-	}
-}
-
-// LogTransactionReceipt logs log messages contained within a transaction receipt
-func LogTransactionReceipt(t *testing.T, txReceipt *protocol.TransactionReceipt) {
-	transactionID := base58.Encode(txReceipt.Id)
-	if txReceipt.Reverted {
-		t.Logf("Transaction: " + transactionID + " (reverted)")
-	} else {
-		t.Logf("Transaction: " + transactionID)
-	}
-
-	t.Logf("Compute: " + strconv.FormatUint(txReceipt.ComputeBandwidthUsed, 10))
-	t.Logf("Disk: " + strconv.FormatUint(txReceipt.DiskStorageUsed, 10))
-	t.Logf("Network: " + strconv.FormatUint(txReceipt.NetworkBandwidthUsed, 10))
-
-	if len(txReceipt.Logs) > 0 {
-		t.Logf(" * Logs")
-		for _, log := range txReceipt.Logs {
-			t.Logf("   - " + log)
-		}
-	}
-
-	if len(txReceipt.Events) > 0 {
-		t.Logf(" * Events")
-		for _, event := range txReceipt.Events {
-			bytes := base64.StdEncoding.EncodeToString(event.Data)
-			t.Logf("   - " + event.Name + ": " + bytes)
-		}
-	}
-}
-
 // LogProto logs a protobuf message
 func LogProto(t *testing.T, message protoreflect.ProtoMessage) {
 	text, err := protojson.Marshal(message)
@@ -1110,10 +1160,8 @@ func LogProto(t *testing.T, message protoreflect.ProtoMessage) {
 
 // NoError asserts err is nil, logging any logs in the process
 func NoError(t *testing.T, err error) {
-	var rpcErr kjsonrpc.KoinosRPCError
-
-	if err != nil && errors.As(err, &rpcErr) {
-		for _, l := range rpcErr.Logs {
+	if chainErr, ok := AsChainError(err); ok {
+		for _, l := range chainErr.Logs {
 			t.Logf(l)
 		}
 	}
@@ -0,0 +1,261 @@
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	chainrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	util "github.com/koinos/koinos-util-golang/v2"
+)
+
+// DisableInvariantsEnv disables invariants by name, as a comma separated list, or all of them when "all"
+const DisableInvariantsEnv = "KOINOS_DISABLE_INVARIANTS"
+
+// BlockCheck is the block an invariant checks, and the head before and after it was submitted
+type BlockCheck struct {
+	Client   Client
+	Block    *protocol.Block
+	Receipt  *protocol.BlockReceipt
+	Previous *koinos.BlockTopology
+	Head     *chainrpc.GetHeadInfoResponse
+}
+
+// Extends returns true if the block was built on the previous head
+func (c *BlockCheck) Extends() bool {
+	return bytes.Equal(c.Block.GetHeader().GetPrevious(), c.Previous.GetId())
+}
+
+// IsHead returns true if the block is the head after it was submitted
+func (c *BlockCheck) IsHead() bool {
+	return bytes.Equal(c.Head.GetHeadTopology().GetId(), c.Receipt.GetId())
+}
+
+// Invariant is checked after every block created through a client bound to a test with ForTest.
+// Check returns an error describing the offending values when the invariant does not hold.
+type Invariant struct {
+	Name  string
+	Check func(check *BlockCheck) error
+}
+
+var (
+	// HeadHeightInvariant requires the head height to increase with a block built on the head,
+	// and to never decrease
+	HeadHeightInvariant = Invariant{Name: "head_height", Check: checkHeadHeight}
+	// NonceInvariant requires the nonce of each account with transactions in a block to be the
+	// highest nonce of those transactions once the block is the head
+	NonceInvariant = Invariant{Name: "nonce", Check: checkNonces}
+	// RcInvariant requires every transaction to use no more rc than its limit, and to limit
+	// itself to no more than the payer's rc
+	RcInvariant = Invariant{Name: "rc", Check: checkRc}
+	// StateRootInvariant requires the state merkle root to change if and only if the block has
+	// transactions, events or state delta entries. System calls made while applying the block
+	// report their changes as state delta entries.
+	StateRootInvariant = Invariant{Name: "state_root", Check: checkStateRoot}
+)
+
+var invariants = struct {
+	sync.Mutex
+	registered map[*testing.T][]Invariant
+	disabled   map[*testing.T]map[string]struct{}
+}{
+	registered: make(map[*testing.T][]Invariant),
+	disabled:   make(map[*testing.T]map[string]struct{}),
+}
+
+// DefaultInvariants returns the invariants checked for every test
+func DefaultInvariants() []Invariant {
+	return []Invariant{HeadHeightInvariant, NonceInvariant, RcInvariant, StateRootInvariant}
+}
+
+// RegisterInvariant checks the invariant after every block created for the rest of the test,
+// through clients bound to the test with ForTest
+func RegisterInvariant(t *testing.T, invariant Invariant) {
+	invariants.Lock()
+	defer invariants.Unlock()
+
+	if _, ok := invariants.registered[t]; !ok {
+		t.Cleanup(func() { forgetInvariants(t) })
+	}
+
+	invariants.registered[t] = append(invariants.registered[t], invariant)
+}
+
+// DisableInvariant stops checking the named invariant for the rest of the test
+func DisableInvariant(t *testing.T, name string) {
+	invariants.Lock()
+	defer invariants.Unlock()
+
+	if _, ok := invariants.disabled[t]; !ok {
+		invariants.disabled[t] = make(map[string]struct{})
+		t.Cleanup(func() { forgetInvariants(t) })
+	}
+
+	invariants.disabled[t][name] = struct{}{}
+}
+
+func forgetInvariants(t *testing.T) {
+	invariants.Lock()
+	defer invariants.Unlock()
+
+	delete(invariants.registered, t)
+	delete(invariants.disabled, t)
+}
+
+// testInvariants returns the invariants to check for the test
+func testInvariants(t *testing.T) []Invariant {
+	env := os.Getenv(DisableInvariantsEnv)
+	if env == "all" {
+		return nil
+	}
+
+	disabled := make(map[string]struct{})
+	for _, name := range strings.Split(env, ",") {
+		disabled[strings.TrimSpace(name)] = struct{}{}
+	}
+
+	invariants.Lock()
+	defer invariants.Unlock()
+
+	for name := range invariants.disabled[t] {
+		disabled[name] = struct{}{}
+	}
+
+	var result []Invariant
+	for _, invariant := range append(DefaultInvariants(), invariants.registered[t]...) {
+		if _, ok := disabled[invariant.Name]; !ok {
+			result = append(result, invariant)
+		}
+	}
+
+	return result
+}
+
+// invariantTest returns the test whose invariants are checked for blocks created by the client,
+// or nil if the client is not bound to a test
+func invariantTest(client Client) *testing.T {
+	if c, ok := client.(*contextClient); ok {
+		return c.t
+	}
+
+	return nil
+}
+
+// checkInvariants checks the invariants of the test after a block, reporting the receipt and
+// offending values of each that does not hold
+func checkInvariants(t *testing.T, check *BlockCheck) {
+	t.Helper()
+
+	checks := testInvariants(t)
+	if len(checks) == 0 {
+		return
+	}
+
+	head, err := GetHeadInfo(check.Client)
+	if err != nil {
+		t.Errorf("checking invariants of block %d: %s", check.Receipt.GetHeight(), err)
+		return
+	}
+	check.Head = head
+
+	logged := false
+	for _, invariant := range checks {
+		if err := invariant.Check(check); err != nil {
+			if !logged {
+				LogBlockReceipt(t, check.Receipt)
+				logged = true
+			}
+
+			t.Errorf("invariant %s does not hold after block %d: %s", invariant.Name, check.Receipt.GetHeight(), err)
+		}
+	}
+}
+
+func checkHeadHeight(check *BlockCheck) error {
+	previous, head := check.Previous.GetHeight(), check.Head.GetHeadTopology().GetHeight()
+
+	if check.Extends() && head <= previous {
+		return fmt.Errorf("head height %d after a block on head height %d", head, previous)
+	}
+
+	if head < previous {
+		return fmt.Errorf("head height decreased from %d to %d", previous, head)
+	}
+
+	return nil
+}
+
+func checkNonces(check *BlockCheck) error {
+	if !check.IsHead() {
+		return nil
+	}
+
+	expected := make(map[string]uint64)
+	var accounts [][]byte
+	for _, transaction := range check.Block.GetTransactions() {
+		account := transaction.GetHeader().GetPayee()
+		if len(account) == 0 {
+			account = transaction.GetHeader().GetPayer()
+		}
+
+		nonce, err := util.NonceBytesToUInt64(transaction.GetHeader().GetNonce())
+		if err != nil {
+			return err
+		}
+
+		if _, ok := expected[string(account)]; !ok {
+			accounts = append(accounts, account)
+		}
+		if nonce > expected[string(account)] {
+			expected[string(account)] = nonce
+		}
+	}
+
+	for _, account := range accounts {
+		nonce, err := GetAccountNonce(check.Client, account)
+		if err != nil {
+			return err
+		}
+
+		if nonce != expected[string(account)] {
+			return fmt.Errorf("nonce of %s is %d, expected %d", base58.Encode(account), nonce, expected[string(account)])
+		}
+	}
+
+	return nil
+}
+
+func checkRc(check *BlockCheck) error {
+	for _, receipt := range check.Receipt.GetTransactionReceipts() {
+		if receipt.GetRcUsed() > receipt.GetRcLimit() {
+			return fmt.Errorf("transaction %x used rc %d above its limit %d", receipt.GetId(), receipt.GetRcUsed(), receipt.GetRcLimit())
+		}
+
+		if receipt.GetMaxPayerRc() > 0 && receipt.GetRcLimit() > receipt.GetMaxPayerRc() {
+			return fmt.Errorf("transaction %x has rc limit %d above payer rc %d", receipt.GetId(), receipt.GetRcLimit(), receipt.GetMaxPayerRc())
+		}
+	}
+
+	return nil
+}
+
+func checkStateRoot(check *BlockCheck) error {
+	changing := len(check.Block.GetTransactions()) > 0 || len(check.Receipt.GetEvents()) > 0 || len(check.Receipt.GetStateDeltaEntries()) > 0
+	changed := !bytes.Equal(check.Receipt.GetStateMerkleRoot(), check.Block.GetHeader().GetPreviousStateMerkleRoot())
+
+	if changing && !changed {
+		return fmt.Errorf("state merkle root %x unchanged by %d transactions and %d events", check.Receipt.GetStateMerkleRoot(), len(check.Block.GetTransactions()), len(check.Receipt.GetEvents()))
+	}
+
+	if !changing && changed {
+		return fmt.Errorf("state merkle root changed from %x to %x without state changes", check.Block.GetHeader().GetPreviousStateMerkleRoot(), check.Receipt.GetStateMerkleRoot())
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	chainrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvariants(t *testing.T) {
+	client := integration.ForTest(t, fake.NewClient())
+
+	key, err := integration.NewKeyring(t.Name()).Key("alice")
+	require.NoError(t, err)
+
+	checked := 0
+	integration.RegisterInvariant(t, integration.Invariant{
+		Name: "counted",
+		Check: func(check *integration.BlockCheck) error {
+			checked++
+			return nil
+		},
+	})
+
+	t.Logf("Checking empty blocks, blocks with transactions and forks")
+	genesis, err := integration.CreateBlockWith(client, nil)
+	require.NoError(t, err)
+
+	transaction, err := integration.CreateTransactionWith(client, []*protocol.Operation{{}}, integration.WithSigner(key))
+	require.NoError(t, err)
+
+	_, err = integration.CreateBlockWith(client, []*protocol.Transaction{transaction})
+	require.NoError(t, err)
+
+	_, err = integration.CreateBlockWith(client, nil, integration.WithParent(genesis))
+	require.NoError(t, err)
+	require.Equal(t, 3, checked)
+
+	t.Logf("Disabling an invariant")
+	integration.DisableInvariant(t, "counted")
+	_, err = integration.CreateBlockWith(client, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, checked)
+
+	t.Logf("Reporting the offending values")
+	nonce, err := util.UInt64ToNonceBytes(5)
+	require.NoError(t, err)
+
+	check := &integration.BlockCheck{
+		Client: fake.NewClient(),
+		Block: &protocol.Block{
+			Header:       &protocol.BlockHeader{Previous: []byte{1}, PreviousStateMerkleRoot: []byte{2}},
+			Transactions: []*protocol.Transaction{{Header: &protocol.TransactionHeader{Payer: key.AddressBytes(), Nonce: nonce}}},
+		},
+		Receipt: &protocol.BlockReceipt{
+			Id:                  []byte{3},
+			StateMerkleRoot:     []byte{2},
+			TransactionReceipts: []*protocol.TransactionReceipt{{RcLimit: 10, RcUsed: 11}},
+		},
+		Previous: &koinos.BlockTopology{Id: []byte{1}, Height: 5},
+		Head:     &chainrpc.GetHeadInfoResponse{HeadTopology: &koinos.BlockTopology{Id: []byte{3}, Height: 5}},
+	}
+
+	for _, c := range []struct {
+		invariant integration.Invariant
+		message   string
+	}{
+		{integration.HeadHeightInvariant, "head height 5 after a block on head height 5"},
+		{integration.NonceInvariant, "is 0, expected 5"},
+		{integration.RcInvariant, "used rc 11 above its limit 10"},
+		{integration.StateRootInvariant, "unchanged by 1 transactions"},
+	} {
+		err := c.invariant.Check(check)
+		require.Error(t, err, c.invariant.Name)
+		require.Contains(t, err.Error(), c.message)
+	}
+
+	t.Logf("Reporting a state merkle root changed without state changes")
+	check.Block.Transactions = nil
+	check.Receipt.StateMerkleRoot = []byte{4}
+	err = integration.StateRootInvariant.Check(check)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "changed from 02 to 04 without state changes")
+
+	t.Logf("Counting state delta entries of system calls as state changes")
+	check.Receipt.StateDeltaEntries = []*protocol.StateDeltaEntry{{Key: []byte{5}}}
+	require.NoError(t, integration.StateRootInvariant.Check(check))
+}
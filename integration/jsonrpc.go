@@ -0,0 +1,44 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+
+	kjson "github.com/koinos/koinos-proto-golang/v2/encoding/json"
+	jsonrpc "github.com/ybbus/jsonrpc/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// JSONRPCClient is a Client for the koinos JSON-RPC api returning *ChainError on node errors
+type JSONRPCClient struct {
+	client jsonrpc.RPCClient
+}
+
+// NewJSONRPCClient creates a JSONRPCClient for the node at url
+func NewJSONRPCClient(url string) *JSONRPCClient {
+	return &JSONRPCClient{client: jsonrpc.NewClient(url)}
+}
+
+// Call makes a JSON-RPC call, unmarshaling the result into returnType
+func (c *JSONRPCClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	req, err := kjson.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Call(ctx, method, json.RawMessage(req))
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return decodeChainError(resp.Error)
+	}
+
+	raw := json.RawMessage{}
+	if err = resp.GetObject(&raw); err != nil {
+		return err
+	}
+
+	return kjson.Unmarshal([]byte(raw), returnType)
+}
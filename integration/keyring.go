@@ -0,0 +1,255 @@
+package integration
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/btcsuite/btcutil/base58"
+	util "github.com/koinos/koinos-util-golang/v2"
+)
+
+const (
+	// KeySeedEnv sets the seed named keys are derived from
+	KeySeedEnv = "KOINOS_KEY_SEED"
+	// KeysFileEnv is the path of a file of keys loaded into DefaultKeyring, in the format read by Keyring.Load
+	KeysFileEnv = "KOINOS_KEYS_FILE"
+	// KeysEnv is a comma separated list of keys loaded into DefaultKeyring after KeysFileEnv, as name=WIF
+	KeysEnv = "KOINOS_KEYS"
+
+	// DefaultKeySeed is the seed of DefaultKeyring when KeySeedEnv is not set
+	DefaultKeySeed = "koinos-integration-tests"
+)
+
+// roleNames maps the key types of GetKey to their names in the keyring
+var roleNames = map[int]string{
+	Genesis:             "genesis",
+	Governance:          "governance",
+	Koin:                "koin",
+	Pob:                 "pob",
+	PobProducer:         "pob_producer",
+	Pow:                 "pow",
+	Resources:           "resources",
+	Vhp:                 "vhp",
+	Claim:               "claim",
+	ClaimDelegation:     "claim_delegation",
+	NameService:         "name_service",
+	GetContractMetadata: "get_contract_metadata",
+}
+
+var rolesMu sync.Mutex
+
+// RegisterRole adds a key type resolved by GetKey to the key named name in DefaultKeyring.
+// Registering a name again returns the same key type.
+func RegisterRole(name string) int {
+	rolesMu.Lock()
+	defer rolesMu.Unlock()
+
+	keyType := 0
+	for t, n := range roleNames {
+		if n == name {
+			return t
+		}
+
+		if t >= keyType {
+			keyType = t + 1
+		}
+	}
+
+	roleNames[keyType] = name
+	return keyType
+}
+
+// RoleName returns the name of a key type in the keyring
+func RoleName(keyType int) (string, bool) {
+	rolesMu.Lock()
+	defer rolesMu.Unlock()
+
+	name, ok := roleNames[keyType]
+	return name, ok
+}
+
+// Keyring is a set of named keys
+//
+// Keys are either added explicitly, such as the system keys of the genesis data, or derived from
+// the seed and the name on first use. The same seed always derives the same keys, so addresses,
+// transaction ids and receipts are reproducible between runs.
+type Keyring struct {
+	seed string
+
+	mu   sync.Mutex
+	keys map[string]*util.KoinosKey
+}
+
+// NewKeyring creates an empty Keyring deriving keys from seed
+func NewKeyring(seed string) *Keyring {
+	return &Keyring{seed: seed, keys: make(map[string]*util.KoinosKey)}
+}
+
+var (
+	defaultKeyring     *Keyring
+	defaultKeyringErr  error
+	defaultKeyringOnce sync.Once
+)
+
+// DefaultKeyring returns the keyring used by GetKey and NamedKey
+//
+// It holds the default system keys, replaced by any keys in the file at KeysFileEnv and then in KeysEnv,
+// and derives other keys from KeySeedEnv or DefaultKeySeed.
+func DefaultKeyring() (*Keyring, error) {
+	defaultKeyringOnce.Do(func() {
+		defaultKeyring, defaultKeyringErr = newDefaultKeyring()
+	})
+
+	return defaultKeyring, defaultKeyringErr
+}
+
+func newDefaultKeyring() (*Keyring, error) {
+	seed := DefaultKeySeed
+	if env, ok := os.LookupEnv(KeySeedEnv); ok {
+		seed = env
+	}
+
+	k := NewKeyring(seed)
+
+	for keyType, wif := range wifMap {
+		if err := k.AddWIF(roleNames[keyType], wif); err != nil {
+			return nil, err
+		}
+	}
+
+	if path := os.Getenv(KeysFileEnv); len(path) > 0 {
+		if err := k.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if env := os.Getenv(KeysEnv); len(env) > 0 {
+		if err := k.Load(strings.NewReader(strings.ReplaceAll(env, ",", "\n"))); err != nil {
+			return nil, fmt.Errorf("%s: %w", KeysEnv, err)
+		}
+	}
+
+	return k, nil
+}
+
+// NamedKey returns the key named name in DefaultKeyring
+func NamedKey(name string) (*util.KoinosKey, error) {
+	k, err := DefaultKeyring()
+	if err != nil {
+		return nil, err
+	}
+
+	return k.Key(name)
+}
+
+// Key returns the key named name, deriving it from the seed if it was not added
+func (k *Keyring) Key(name string) (*util.KoinosKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if key, ok := k.keys[name]; ok {
+		return key, nil
+	}
+
+	private := sha256.Sum256([]byte(k.seed + "/" + name))
+	key, err := util.NewKoinosKeyFromBytes(private[:])
+	if err != nil {
+		return nil, fmt.Errorf("deriving key %s: %w", name, err)
+	}
+
+	k.keys[name] = key
+	return key, nil
+}
+
+// Add sets the key named name, replacing any existing key
+func (k *Keyring) Add(name string, key *util.KoinosKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.keys[name] = key
+}
+
+// AddWIF sets the key named name from a private key WIF
+func (k *Keyring) AddWIF(name string, wif string) error {
+	key, err := KeyFromWIF(wif)
+	if err != nil {
+		return fmt.Errorf("key %s: %w", name, err)
+	}
+
+	k.Add(name, key)
+	return nil
+}
+
+// Load adds the keys read from r, one name=WIF per line. Blank lines and lines starting with # are ignored.
+func (k *Keyring) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		name, wif, ok := strings.Cut(text, "=")
+		if !ok {
+			return fmt.Errorf("line %d: expected name=WIF", line)
+		}
+
+		if err := k.AddWIF(strings.TrimSpace(name), strings.TrimSpace(wif)); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// LoadFile adds the keys in the file at path, as read by Load
+func (k *Keyring) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = k.Load(file); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Names returns the names of the keys in the keyring, sorted
+func (k *Keyring) Names() []string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	names := make([]string, 0, len(k.keys))
+	for name := range k.keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Export writes every key in the format read by Load, in the order of Names, with a comment
+// giving the base58 address of each key
+func (k *Keyring) Export(w io.Writer) error {
+	for _, name := range k.Names() {
+		key, err := k.Key(name)
+		if err != nil {
+			return err
+		}
+
+		if _, err = fmt.Fprintf(w, "# %s %s\n%s=%s\n", name, base58.Encode(key.AddressBytes()), name, key.Private()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package integration
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring(t *testing.T) {
+	keyring := NewKeyring("seed")
+
+	alice, err := keyring.Key("alice")
+	require.NoError(t, err)
+
+	t.Logf("Deriving keys deterministically")
+	again, err := NewKeyring("seed").Key("alice")
+	require.NoError(t, err)
+	require.Equal(t, alice.AddressBytes(), again.AddressBytes())
+
+	bob, err := keyring.Key("bob")
+	require.NoError(t, err)
+	require.NotEqual(t, alice.AddressBytes(), bob.AddressBytes())
+
+	other, err := NewKeyring("other").Key("alice")
+	require.NoError(t, err)
+	require.NotEqual(t, alice.AddressBytes(), other.AddressBytes())
+
+	t.Logf("Round tripping exported keys")
+	producer, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+	keyring.Add("producer-3", producer)
+	require.Equal(t, []string{"alice", "bob", "producer-3"}, keyring.Names())
+
+	var exported bytes.Buffer
+	require.NoError(t, keyring.Export(&exported))
+	require.Contains(t, exported.String(), "producer-3="+producer.Private())
+
+	loaded := NewKeyring("unused")
+	require.NoError(t, loaded.Load(&exported))
+	require.Equal(t, keyring.Names(), loaded.Names())
+
+	key, err := loaded.Key("producer-3")
+	require.NoError(t, err)
+	require.Equal(t, producer.AddressBytes(), key.AddressBytes())
+
+	t.Logf("Rejecting malformed keys")
+	err = loaded.Load(strings.NewReader("# comment\n\nalice\n"))
+	require.ErrorContains(t, err, "line 3")
+
+	err = loaded.Load(strings.NewReader("alice=notawif"))
+	require.ErrorContains(t, err, "line 1: key alice")
+}
+
+func TestDefaultKeyring(t *testing.T) {
+	replacement, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	extra, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keys")
+	require.NoError(t, os.WriteFile(path, []byte("koin="+replacement.Private()+"\n"), 0o600))
+
+	t.Setenv(KeySeedEnv, "test")
+	t.Setenv(KeysFileEnv, path)
+	t.Setenv(KeysEnv, "producer-3="+extra.Private())
+
+	keyring, err := newDefaultKeyring()
+	require.NoError(t, err)
+
+	t.Logf("Loading system keys from the file and environment")
+	genesis, err := keyring.Key("genesis")
+	require.NoError(t, err)
+	expected, err := KeyFromWIF(wifMap[Genesis])
+	require.NoError(t, err)
+	require.Equal(t, expected.AddressBytes(), genesis.AddressBytes())
+
+	koin, err := keyring.Key("koin")
+	require.NoError(t, err)
+	require.Equal(t, replacement.AddressBytes(), koin.AddressBytes())
+
+	producer, err := keyring.Key("producer-3")
+	require.NoError(t, err)
+	require.Equal(t, extra.AddressBytes(), producer.AddressBytes())
+
+	alice, err := keyring.Key("alice")
+	require.NoError(t, err)
+	derived, err := NewKeyring("test").Key("alice")
+	require.NoError(t, err)
+	require.Equal(t, derived.AddressBytes(), alice.AddressBytes())
+
+	t.Logf("Resolving registered roles")
+	role := RegisterRole("producer-3")
+	require.Greater(t, role, GetContractMetadata)
+	require.Equal(t, role, RegisterRole("producer-3"))
+
+	name, ok := RoleName(role)
+	require.True(t, ok)
+	require.Equal(t, "producer-3", name)
+
+	key, err := GetKey(role)
+	require.NoError(t, err)
+	named, err := NamedKey("producer-3")
+	require.NoError(t, err)
+	require.Equal(t, named.AddressBytes(), key.AddressBytes())
+
+	_, err = GetKey(role + 1)
+	require.Error(t, err)
+}
@@ -0,0 +1,149 @@
+package integration
+
+import (
+	"math/big"
+)
+
+// Parameters of the resource markets of the resources contract
+const (
+	MarketTokenSupply         = 5000000000000000
+	MarketTokenSupplyPerBlock = MarketTokenSupply / (86400 * 5 * 3 / 3)
+
+	// MarketDecayConstant is (2 ^ (-1 / num_blocks)) * 2^64, for a half life of 3 days
+	MarketDecayConstant = 18446596084619782819
+	// MarketOneMinusDecayConstant is (1 - (2 ^ (-1 / num_blocks))) * 2^64
+	MarketOneMinusDecayConstant = 147989089768795
+
+	DiskBudgetPerBlock    = 39600
+	MaxDiskPerBlock       = 1 << 19
+	NetworkBudgetPerBlock = 1 << 18
+	MaxNetworkPerBlock    = 1 << 20
+	ComputeBudgetPerBlock = 57500000
+	MaxComputePerBlock    = 287500000
+
+	PrintRatePremium   = 1688
+	PrintRatePrecision = 1000
+)
+
+var (
+	decayConstant         = new(big.Int).SetUint64(MarketDecayConstant)
+	oneMinusDecayConstant = big.NewInt(MarketOneMinusDecayConstant)
+)
+
+// ResourceMarket models a resource market of the resources contract
+type ResourceMarket struct {
+	Budget    uint64
+	Limit     uint64
+	PrintRate uint64
+	Supply    uint64
+}
+
+// NewResourceMarket returns a market at its initial supply
+func NewResourceMarket(budget uint64, limit uint64) *ResourceMarket {
+	printRate := budget * PrintRatePremium / PrintRatePrecision
+
+	supply := new(big.Int).Lsh(new(big.Int).SetUint64(printRate), 64)
+	supply.Quo(supply, oneMinusDecayConstant)
+
+	return &ResourceMarket{Budget: budget, Limit: limit, PrintRate: printRate, Supply: supply.Uint64()}
+}
+
+// premium returns the supply printed above the budget, at equilibrium
+func (m *ResourceMarket) premium() *big.Int {
+	p := new(big.Int).Lsh(new(big.Int).SetUint64(m.PrintRate-m.Budget), 64)
+	return p.Quo(p, oneMinusDecayConstant)
+}
+
+// K returns the constant product of the market
+func (m *ResourceMarket) K() *big.Int {
+	p := m.premium()
+
+	k := new(big.Int).Mul(big.NewInt(MarketTokenSupplyPerBlock), p)
+	k.Quo(k, new(big.Int).SetUint64(m.Budget))
+	return k.Mul(k, p.Sub(p, new(big.Int).SetUint64(m.Budget)))
+}
+
+// Cost returns the resource limit of a block and the rc cost of each unit of the resource
+func (m *ResourceMarket) Cost() (limit uint64, cost uint64) {
+	limit = m.Limit
+	if m.Supply-1 < limit {
+		limit = m.Supply - 1
+	}
+
+	k := m.K()
+	supply := new(big.Int).SetUint64(m.Supply)
+	newSupply := new(big.Int).SetUint64(m.Supply - limit)
+
+	consumed := ceilQuo(k, newSupply)
+	consumed.Sub(consumed, new(big.Int).Quo(k, supply))
+
+	return limit, ceilQuo(consumed, new(big.Int).SetUint64(limit)).Uint64()
+}
+
+// Consume decays the supply and adds the block's print rate, less the consumed resource
+func (m *ResourceMarket) Consume(consumed uint64) {
+	supply := new(big.Int).Mul(new(big.Int).SetUint64(m.Supply), decayConstant)
+	m.Supply = supply.Rsh(supply, 64).Uint64() + m.PrintRate - consumed
+}
+
+// OverflowTokenLimit returns the token supply above which the market's constant product overflows 128 bits
+func (m *ResourceMarket) OverflowTokenLimit() *big.Int {
+	limit := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	limit.Quo(limit, m.premium())
+	return limit.Mul(limit, big.NewInt(86400*5*3/3))
+}
+
+func ceilQuo(x *big.Int, y *big.Int) *big.Int {
+	q := new(big.Int).Add(x, y)
+	q.Sub(q, big.NewInt(1))
+	return q.Quo(q, y)
+}
+
+// ResourceMarkets models the disk, network and compute markets of the resources contract
+type ResourceMarkets struct {
+	Disk    *ResourceMarket
+	Network *ResourceMarket
+	Compute *ResourceMarket
+}
+
+// NewResourceMarkets returns the markets at their initial supply
+func NewResourceMarkets() *ResourceMarkets {
+	return &ResourceMarkets{
+		Disk:    NewResourceMarket(DiskBudgetPerBlock, MaxDiskPerBlock),
+		Network: NewResourceMarket(NetworkBudgetPerBlock, MaxNetworkPerBlock),
+		Compute: NewResourceMarket(ComputeBudgetPerBlock, MaxComputePerBlock),
+	}
+}
+
+// Consume updates the markets with the resources consumed by a block
+func (m *ResourceMarkets) Consume(disk uint64, network uint64, compute uint64) {
+	m.Disk.Consume(disk)
+	m.Network.Consume(network)
+	m.Compute.Consume(compute)
+}
+
+// MarketValues are the supplies of the resource markets and the costs of their resources
+type MarketValues struct {
+	DiskSupply    uint64 `json:"disk_supply"`
+	DiskCost      uint64 `json:"disk_cost"`
+	NetworkSupply uint64 `json:"network_supply"`
+	NetworkCost   uint64 `json:"network_cost"`
+	ComputeSupply uint64 `json:"compute_supply"`
+	ComputeCost   uint64 `json:"compute_cost"`
+}
+
+// Values returns the supplies and costs of the markets
+func (m *ResourceMarkets) Values() MarketValues {
+	_, diskCost := m.Disk.Cost()
+	_, networkCost := m.Network.Cost()
+	_, computeCost := m.Compute.Cost()
+
+	return MarketValues{
+		DiskSupply:    m.Disk.Supply,
+		DiskCost:      diskCost,
+		NetworkSupply: m.Network.Supply,
+		NetworkCost:   networkCost,
+		ComputeSupply: m.Compute.Supply,
+		ComputeCost:   computeCost,
+	}
+}
@@ -0,0 +1,43 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceMarkets(t *testing.T) {
+	markets := integration.NewResourceMarkets()
+
+	t.Logf("Starting at the initial supply")
+	require.EqualValues(t, 8332061253, markets.Disk.Supply)
+	require.EqualValues(t, 55157213404, markets.Network.Supply)
+	require.EqualValues(t, 12098466059839, markets.Compute.Supply)
+
+	t.Logf("Matching the vectors of the resources contract")
+	expected := map[int]integration.MarketValues{
+		0:  {DiskSupply: 8332061147, DiskCost: 48555, NetworkSupply: 55157212842, NetworkCost: 7335, ComputeSupply: 12098465557067, ComputeCost: 34},
+		1:  {DiskSupply: 8332061147, DiskCost: 48555, NetworkSupply: 55157212280, NetworkCost: 7335, ComputeSupply: 12098465046640, ComputeCost: 34},
+		51: {DiskSupply: 8332061147, DiskCost: 48555, NetworkSupply: 55157184180, NetworkCost: 7335, ComputeSupply: 12098439529955, ComputeCost: 34},
+		99: {DiskSupply: 8332061147, DiskCost: 48555, NetworkSupply: 55157157204, NetworkCost: 7335, ComputeSupply: 12098415018545, ComputeCost: 34},
+	}
+
+	for i := 0; i < 100; i++ {
+		switch {
+		case i == 0:
+			markets.Consume(106, 562, 502771)
+		case i <= 50:
+			markets.Consume(0, 562, 510431)
+		default:
+			markets.Consume(0, 562, 510963)
+		}
+
+		if values, ok := expected[i]; ok {
+			require.Equal(t, values, markets.Values(), "block %d", i)
+		}
+	}
+
+	limit, _ := markets.Disk.Cost()
+	require.EqualValues(t, integration.MaxDiskPerBlock, limit)
+}
@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// CreateUnsignedTransaction creates a transaction from a list of operations to be signed by each
+// party with SignTransaction. The payer is required. The nonce is that of the payee, if set with
+// WithPayee, or the payer.
+func CreateUnsignedTransaction(client Client, ops []*protocol.Operation, opts ...TransactionOption) (*protocol.Transaction, error) {
+	options := newTransactionOptions(opts)
+	if len(options.signers) > 0 {
+		return nil, fmt.Errorf("unexpected signers of an unsigned transaction")
+	}
+
+	if options.payer == nil {
+		return nil, fmt.Errorf("expected a payer")
+	}
+
+	return createTransaction(client, ops, options)
+}
+
+// MarshalTransaction serializes a transaction to pass to another signer
+func MarshalTransaction(transaction *protocol.Transaction) ([]byte, error) {
+	return proto.Marshal(transaction)
+}
+
+// UnmarshalTransaction deserializes a transaction serialized by MarshalTransaction
+func UnmarshalTransaction(data []byte) (*protocol.Transaction, error) {
+	transaction := &protocol.Transaction{}
+	if err := proto.Unmarshal(data, transaction); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// SignTransaction returns a copy of the transaction with the signature of key added. The transaction
+// id must match its header, so a transaction altered after it was created is not signed.
+func SignTransaction(transaction *protocol.Transaction, key *util.KoinosKey) (*protocol.Transaction, error) {
+	id, err := transactionID(transaction.GetHeader())
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(id, transaction.Id) {
+		return nil, fmt.Errorf("transaction id does not match its header")
+	}
+
+	signed := proto.Clone(transaction).(*protocol.Transaction)
+	if err = util.SignTransaction(key.PrivateBytes(), signed); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// MergeSignatures combines the signatures of copies of the same transaction, signed independently.
+// Signatures keep the order of the transactions, and a signature present in several copies is kept once.
+func MergeSignatures(transactions ...*protocol.Transaction) (*protocol.Transaction, error) {
+	if len(transactions) == 0 {
+		return nil, fmt.Errorf("expected at least one transaction")
+	}
+
+	unsigned := proto.Clone(transactions[0]).(*protocol.Transaction)
+	unsigned.Signatures = nil
+	merged := proto.Clone(unsigned).(*protocol.Transaction)
+
+	for i, transaction := range transactions {
+		other := proto.Clone(transaction).(*protocol.Transaction)
+		other.Signatures = nil
+		if !proto.Equal(other, unsigned) {
+			return nil, fmt.Errorf("transaction %d differs from transaction 0", i)
+		}
+
+		for _, signature := range transaction.Signatures {
+			found := false
+			for _, existing := range merged.Signatures {
+				if bytes.Equal(existing, signature) {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				merged.Signatures = append(merged.Signatures, signature)
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// RecoverAddress returns the address that signed the multihash id with a compact signature
+func RecoverAddress(id []byte, signature []byte) ([]byte, error) {
+	decoded, err := multihash.Decode(id)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, _, err := btcec.RecoverCompact(btcec.S256(), signature, decoded.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := btcutil.NewAddressPubKey(pubKey.SerializeCompressed(), &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return base58.Decode(address.EncodeAddress()), nil
+}
+
+// RecoverSigners returns the addresses that signed the transaction, in the order of its signatures
+func RecoverSigners(transaction *protocol.Transaction) ([][]byte, error) {
+	signers := make([][]byte, len(transaction.Signatures))
+	for i, signature := range transaction.Signatures {
+		address, err := RecoverAddress(transaction.Id, signature)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+
+		signers[i] = address
+	}
+
+	return signers, nil
+}
+
+// RequireSigners asserts the transaction is signed by exactly the addresses, in order
+func RequireSigners(t *testing.T, transaction *protocol.Transaction, addresses ...[]byte) {
+	signers, err := RecoverSigners(transaction)
+	NoError(t, err)
+
+	expected := make([]string, len(addresses))
+	for i, address := range addresses {
+		expected[i] = base58.Encode(address)
+	}
+
+	actual := make([]string, len(signers))
+	for i, signer := range signers {
+		actual[i] = base58.Encode(signer)
+	}
+
+	require.Equal(t, expected, actual, "unexpected transaction signers")
+}
@@ -0,0 +1,106 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMultisig(t *testing.T) {
+	client := fake.NewClient()
+
+	keyring := integration.NewKeyring(t.Name())
+
+	alice, err := keyring.Key("alice")
+	require.NoError(t, err)
+
+	bob, err := keyring.Key("bob")
+	require.NoError(t, err)
+
+	carol, err := keyring.Key("carol")
+	require.NoError(t, err)
+
+	t.Logf("Creating an unsigned transaction paid by bob")
+	transaction, err := integration.CreateUnsignedTransaction(
+		client,
+		[]*protocol.Operation{},
+		integration.WithPayer(bob.AddressBytes()),
+		integration.WithPayee(alice.AddressBytes()),
+		integration.WithRcLimit(100),
+	)
+	require.NoError(t, err)
+	require.Empty(t, transaction.Signatures)
+	require.Equal(t, bob.AddressBytes(), transaction.Header.Payer)
+	require.Equal(t, alice.AddressBytes(), transaction.Header.Payee)
+
+	data, err := integration.MarshalTransaction(transaction)
+	require.NoError(t, err)
+
+	t.Logf("Signing independently")
+	sign := func(key *util.KoinosKey) *protocol.Transaction {
+		received, err := integration.UnmarshalTransaction(data)
+		require.NoError(t, err)
+
+		signed, err := integration.SignTransaction(received, key)
+		require.NoError(t, err)
+		require.Empty(t, received.Signatures)
+		integration.RequireSigners(t, signed, key.AddressBytes())
+
+		return signed
+	}
+
+	carolSigned := sign(carol)
+	bobSigned := sign(bob)
+	aliceSigned := sign(alice)
+
+	t.Logf("Rejecting a partially signed transaction")
+	_, err = integration.SubmitTransaction(client, aliceSigned)
+	require.ErrorIs(t, err, fake.ErrInvalidTransaction)
+
+	_, err = integration.SubmitTransaction(client, bobSigned)
+	require.ErrorIs(t, err, fake.ErrInvalidTransaction)
+
+	t.Logf("Merging signatures out of order")
+	merged, err := integration.MergeSignatures(carolSigned, bobSigned, aliceSigned, bobSigned)
+	require.NoError(t, err)
+	integration.RequireSigners(t, merged, carol.AddressBytes(), bob.AddressBytes(), alice.AddressBytes())
+
+	reordered, err := integration.MergeSignatures(aliceSigned, merged)
+	require.NoError(t, err)
+	integration.RequireSigners(t, reordered, alice.AddressBytes(), carol.AddressBytes(), bob.AddressBytes())
+
+	_, err = integration.SubmitTransaction(client, merged)
+	require.NoError(t, err)
+
+	nonce, err := integration.GetPendingNonce(client, alice.AddressBytes())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, nonce)
+
+	t.Logf("Rejecting mismatched transactions")
+	tampered := proto.Clone(transaction).(*protocol.Transaction)
+	tampered.Header.RcLimit++
+	_, err = integration.SignTransaction(tampered, alice)
+	require.Error(t, err)
+
+	other, err := integration.CreateUnsignedTransaction(client, []*protocol.Operation{}, integration.WithPayer(bob.AddressBytes()), integration.WithRcLimit(100))
+	require.NoError(t, err)
+	require.Empty(t, other.Header.Payee)
+
+	otherSigned, err := integration.SignTransaction(other, bob)
+	require.NoError(t, err)
+
+	_, err = integration.MergeSignatures(bobSigned, otherSigned)
+	require.Error(t, err)
+
+	t.Logf("Requiring a payer and no signers")
+	_, err = integration.CreateUnsignedTransaction(client, []*protocol.Operation{}, integration.WithPayee(alice.AddressBytes()))
+	require.Error(t, err)
+
+	_, err = integration.CreateUnsignedTransaction(client, []*protocol.Operation{}, integration.WithSigner(alice), integration.WithPayer(bob.AddressBytes()))
+	require.Error(t, err)
+}
@@ -1,82 +1,57 @@
 package name_service
 
 import (
+	"context"
 	"koinos-integration-tests/integration"
 	"testing"
 
 	name_service "github.com/koinos/koinos-proto-golang/v2/koinos/contracts/name-service"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
 	util "github.com/koinos/koinos-util-golang/v2"
-	"google.golang.org/protobuf/proto"
 )
 
-const (
-	SetRecordEntry  uint32 = 0xe248c73a
-	GetNameEntry    uint32 = 0xe5070a16
-	GetAddressEntry uint32 = 0xa61ae5e8
-)
+//go:generate go run ../../cmd/koinos-abigen -abi name_service.abi.json -out name_service_abi.go
 
 // A wrapper around the NameService contract
 type NameService struct {
-	key    *util.KoinosKey
-	client integration.Client
+	key      *util.KoinosKey
+	client   integration.Client
+	contract *NameServiceContract
 }
 
 // GetGoverance returns the goverance contract object
 func GetNameService(client integration.Client) *NameService {
 	nameServiceKey, _ := integration.GetKey(integration.NameService)
 
-	return &NameService{key: nameServiceKey, client: client}
+	return &NameService{key: nameServiceKey, client: client, contract: NewNameServiceContract(client, nameServiceKey.AddressBytes())}
+}
+
+// WithContext returns a copy of the name service whose calls are made within ctx
+func (n *NameService) WithContext(ctx context.Context) *NameService {
+	return n.withClient(integration.WithContext(n.client, ctx))
+}
+
+func (n *NameService) withClient(client integration.Client) *NameService {
+	return &NameService{key: n.key, client: client, contract: NewNameServiceContract(client, n.contract.Address)}
 }
 
 // SetRecord sets a record in the name service
 func (n *NameService) SetRecord(t *testing.T, payer *util.KoinosKey, name string, address []byte) (*protocol.BlockReceipt, error) {
-	setRecordArgs := &name_service.SetRecordArguments{
+	n = n.withClient(integration.ForTest(t, n.client))
+
+	return n.contract.SetRecord(&name_service.SetRecordArguments{
 		Name:    name,
 		Address: address,
-	}
-
-	args, err := proto.Marshal(setRecordArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	op := &protocol.Operation{
-		Op: &protocol.Operation_CallContract{
-			CallContract: &protocol.CallContractOperation{
-				ContractId: n.key.AddressBytes(),
-				EntryPoint: SetRecordEntry,
-				Args:       args,
-			},
-		},
-	}
-
-	transaction, err := integration.CreateTransaction(n.client, []*protocol.Operation{op}, payer)
-	if err != nil {
-		return nil, err
-	}
-
-	return integration.CreateBlock(n.client, []*protocol.Transaction{transaction})
+	}, payer)
 }
 
 // GetName returns the name of the contract at a given address
 func (n *NameService) GetName(t *testing.T, address []byte) (*name_service.NameRecord, error) {
-	getContractNameArgs := &name_service.GetNameArguments{
-		Address: address,
-	}
+	n = n.withClient(integration.ForTest(t, n.client))
 
-	args, err := proto.Marshal(getContractNameArgs)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := integration.ReadContract(n.client, args, n.key.AddressBytes(), GetNameEntry)
-	if err != nil {
-		return nil, err
-	}
-
-	result := &name_service.GetNameResult{}
-	err = proto.Unmarshal(resp.GetResult(), result)
+	result, err := n.contract.GetName(&name_service.GetNameArguments{
+		Address: address,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -86,22 +61,11 @@ func (n *NameService) GetName(t *testing.T, address []byte) (*name_service.NameR
 
 // GetAddress returns the name of the contract at a given address
 func (n *NameService) GetAddress(t *testing.T, name string) (*name_service.AddressRecord, error) {
-	getContractAddressArgs := &name_service.GetAddressArguments{
-		Name: name,
-	}
-
-	args, err := proto.Marshal(getContractAddressArgs)
-	if err != nil {
-		return nil, err
-	}
+	n = n.withClient(integration.ForTest(t, n.client))
 
-	resp, err := integration.ReadContract(n.client, args, n.key.AddressBytes(), GetAddressEntry)
-	if err != nil {
-		return nil, err
-	}
-
-	result := &name_service.GetAddressResult{}
-	err = proto.Unmarshal(resp.GetResult(), result)
+	result, err := n.contract.GetAddress(&name_service.GetAddressArguments{
+		Name: name,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,213 @@
+package integration
+
+import (
+	"fmt"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+)
+
+// BlockOption configures a block created by CreateBlockWith
+type BlockOption interface {
+	applyBlock(o *blockOptions)
+}
+
+// TransactionOption configures a transaction created by CreateTransactionWith
+type TransactionOption interface {
+	applyTransaction(o *transactionOptions)
+}
+
+// Option configures both blocks and transactions
+type Option interface {
+	BlockOption
+	TransactionOption
+}
+
+type blockOptions struct {
+	signer    *util.KoinosKey
+	timestamp *uint64
+	parent    *protocol.BlockReceipt
+	mutators  []func(b *protocol.Block) error
+}
+
+type transactionOptions struct {
+	signers  []*util.KoinosKey
+	payer    []byte
+	payee    []byte
+	nonce    *uint64
+	rcLimit  *uint64
+	rcPolicy RcPolicy
+	mutators []func(t *protocol.Transaction) error
+}
+
+func newTransactionOptions(opts []TransactionOption) *transactionOptions {
+	options := &transactionOptions{}
+	for _, opt := range opts {
+		opt.applyTransaction(options)
+	}
+
+	return options
+}
+
+// payerAddress returns the address paying for the transaction, defaulting to the first signer,
+// then the payee
+func (o *transactionOptions) payerAddress() []byte {
+	if o.payer != nil {
+		return o.payer
+	}
+
+	if len(o.signers) > 0 {
+		return o.signers[0].AddressBytes()
+	}
+
+	return o.payee
+}
+
+// nonceAccount returns the address whose nonce the transaction uses, defaulting to the first
+// signer, then the payer
+func (o *transactionOptions) nonceAccount() []byte {
+	if o.payee != nil {
+		return o.payee
+	}
+
+	if len(o.signers) > 0 {
+		return o.signers[0].AddressBytes()
+	}
+
+	return o.payer
+}
+
+type blockOptionFunc func(o *blockOptions)
+
+func (f blockOptionFunc) applyBlock(o *blockOptions) {
+	f(o)
+}
+
+type transactionOptionFunc func(o *transactionOptions)
+
+func (f transactionOptionFunc) applyTransaction(o *transactionOptions) {
+	f(o)
+}
+
+type signerOption struct {
+	key *util.KoinosKey
+}
+
+func (s signerOption) applyBlock(o *blockOptions) {
+	o.signer = s.key
+}
+
+func (s signerOption) applyTransaction(o *transactionOptions) {
+	o.signers = append(o.signers, s.key)
+}
+
+// WithSigner signs the block or transaction with key. Blocks default to the genesis key.
+// A transaction may have several signers, the first pays for the transaction and provides
+// its nonce.
+func WithSigner(key *util.KoinosKey) Option {
+	return signerOption{key: key}
+}
+
+// WithPayer sets the address paying for the transaction. The first signer becomes the payee,
+// providing the nonce.
+func WithPayer(payer []byte) TransactionOption {
+	return transactionOptionFunc(func(o *transactionOptions) {
+		o.payer = payer
+	})
+}
+
+// WithPayee sets the address providing the nonce of the transaction instead of the first signer
+func WithPayee(payee []byte) TransactionOption {
+	return transactionOptionFunc(func(o *transactionOptions) {
+		o.payee = payee
+	})
+}
+
+// WithNonce sets the nonce of the transaction instead of the next account nonce
+func WithNonce(nonce uint64) TransactionOption {
+	return transactionOptionFunc(func(o *transactionOptions) {
+		o.nonce = &nonce
+	})
+}
+
+// WithRcLimit sets the rc limit of the transaction instead of the payer's available rc
+func WithRcLimit(rcLimit uint64) TransactionOption {
+	return transactionOptionFunc(func(o *transactionOptions) {
+		o.rcLimit = &rcLimit
+	})
+}
+
+// WithRcPolicy sets how the rc limit of the transaction is chosen when WithRcLimit is not given
+func WithRcPolicy(policy RcPolicy) TransactionOption {
+	return transactionOptionFunc(func(o *transactionOptions) {
+		o.rcPolicy = policy
+	})
+}
+
+// WithTransactionMutator modifies the transaction before it is signed. Mutators are called in order.
+func WithTransactionMutator(mod func(t *protocol.Transaction) error) TransactionOption {
+	return transactionOptionFunc(func(o *transactionOptions) {
+		o.mutators = append(o.mutators, mod)
+	})
+}
+
+// WithTimestamp sets the block timestamp, in milliseconds, instead of the current time
+func WithTimestamp(timestamp uint64) BlockOption {
+	return blockOptionFunc(func(o *blockOptions) {
+		o.timestamp = &timestamp
+	})
+}
+
+// WithParent builds the block on the block of parent instead of the head block
+func WithParent(parent *protocol.BlockReceipt) BlockOption {
+	return blockOptionFunc(func(o *blockOptions) {
+		o.parent = parent
+	})
+}
+
+// WithBlockMutator modifies the block before it is signed. Mutators are called in order.
+func WithBlockMutator(mod func(b *protocol.Block) error) BlockOption {
+	return blockOptionFunc(func(o *blockOptions) {
+		o.mutators = append(o.mutators, mod)
+	})
+}
+
+// blockOptionsFromVars converts the deprecated variadic arguments of CreateBlock
+func blockOptionsFromVars(vars []interface{}) ([]BlockOption, error) {
+	opts := make([]BlockOption, 0, len(vars))
+
+	for _, v := range vars {
+		switch t := v.(type) {
+		case *util.KoinosKey:
+			opts = append(opts, WithSigner(t))
+		case func(b *protocol.Block) error:
+			opts = append(opts, WithBlockMutator(t))
+		case BlockOption:
+			opts = append(opts, t)
+		default:
+			return nil, fmt.Errorf("unexpected argument of type %T", v)
+		}
+	}
+
+	return opts, nil
+}
+
+// transactionOptionsFromVars converts the deprecated variadic arguments of CreateTransaction
+func transactionOptionsFromVars(vars []interface{}) ([]TransactionOption, error) {
+	opts := make([]TransactionOption, 0, len(vars))
+
+	for _, v := range vars {
+		switch t := v.(type) {
+		case *util.KoinosKey:
+			opts = append(opts, WithSigner(t))
+		case func(t *protocol.Transaction) error:
+			opts = append(opts, WithTransactionMutator(t))
+		case TransactionOption:
+			opts = append(opts, t)
+		default:
+			return nil, fmt.Errorf("unexpected argument of type %T", v)
+		}
+	}
+
+	return opts, nil
+}
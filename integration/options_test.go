@@ -0,0 +1,118 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTransactionOptions(t *testing.T) {
+	client := fake.NewClient()
+
+	signer, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	payer, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	t.Logf("Defaulting the nonce and rc limit")
+	tx, err := integration.CreateTransactionWith(client, []*protocol.Operation{}, integration.WithSigner(signer))
+	require.NoError(t, err)
+	require.Equal(t, signer.AddressBytes(), tx.Header.Payer)
+	require.Empty(t, tx.Header.Payee)
+	require.EqualValues(t, fake.DefaultRc, tx.Header.RcLimit)
+
+	nonce, err := util.NonceBytesToUInt64(tx.Header.Nonce)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, nonce)
+
+	t.Logf("Overriding the payer, nonce and rc limit")
+	var mutated bool
+	tx, err = integration.CreateTransactionWith(
+		client,
+		[]*protocol.Operation{},
+		integration.WithSigner(signer),
+		integration.WithSigner(payer),
+		integration.WithPayer(payer.AddressBytes()),
+		integration.WithNonce(5),
+		integration.WithRcLimit(1000),
+		integration.WithTransactionMutator(func(t *protocol.Transaction) error {
+			mutated = true
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	require.True(t, mutated)
+	require.Equal(t, payer.AddressBytes(), tx.Header.Payer)
+	require.Equal(t, signer.AddressBytes(), tx.Header.Payee)
+	require.EqualValues(t, 1000, tx.Header.RcLimit)
+	require.Len(t, tx.Signatures, 2)
+
+	nonce, err = util.NonceBytesToUInt64(tx.Header.Nonce)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, nonce)
+
+	t.Logf("Requiring a signer")
+	_, err = integration.CreateTransactionWith(client, []*protocol.Operation{})
+	require.Error(t, err)
+
+	t.Logf("Rejecting block arguments in the deprecated form")
+	_, err = integration.CreateTransaction(client, []*protocol.Operation{}, signer, func(b *protocol.Block) error { return nil })
+	require.ErrorContains(t, err, "unexpected argument")
+
+	t.Logf("Accepting options in the deprecated form")
+	tx, err = integration.CreateTransaction(client, []*protocol.Operation{}, signer, integration.WithRcLimit(10))
+	require.NoError(t, err)
+	require.EqualValues(t, 10, tx.Header.RcLimit)
+}
+
+func TestBlockOptions(t *testing.T) {
+	client := fake.NewClient()
+
+	genesisKey, err := integration.GetKey(integration.Genesis)
+	require.NoError(t, err)
+
+	receipts, err := integration.CreateBlocksWith(client, 2)
+	require.NoError(t, err)
+
+	blocks := client.Blocks()
+	require.Equal(t, genesisKey.AddressBytes(), blocks[0].Header.Signer)
+
+	t.Logf("Setting the timestamp and parent")
+	timestamp := uint64(time.Now().Add(time.Minute).UnixMilli())
+	var header *protocol.BlockHeader
+	receipt, err := integration.CreateBlockWith(
+		client,
+		[]*protocol.Transaction{},
+		integration.WithSigner(genesisKey),
+		integration.WithTimestamp(timestamp),
+		integration.WithParent(receipts[1]),
+		integration.WithBlockMutator(func(b *protocol.Block) error {
+			header = b.Header
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, receipt.Height)
+	require.Equal(t, timestamp, header.Timestamp)
+	require.Equal(t, receipts[1].Id, header.Previous)
+	require.Equal(t, receipts[1].StateMerkleRoot, header.PreviousStateMerkleRoot)
+
+	t.Logf("Forking from an earlier parent")
+	receipt, err = integration.CreateBlockWith(client, []*protocol.Transaction{}, integration.WithParent(receipts[0]))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, receipt.Height)
+	require.NotEqual(t, receipts[1].Id, receipt.Id)
+
+	t.Logf("Rejecting an unknown parent")
+	unknown := proto.Clone(receipts[1]).(*protocol.BlockReceipt)
+	unknown.Id = []byte{0x12, 0x20}
+	_, err = integration.CreateBlockWith(client, []*protocol.Transaction{}, integration.WithParent(unknown))
+	require.ErrorIs(t, err, fake.ErrInvalidBlock)
+}
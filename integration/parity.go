@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// readOnlyPrefixes are the prefixes of the methods a ComparingClient sends over both transports
+var readOnlyPrefixes = []string{"get_", "read_", "check_"}
+
+// ForEachTransport runs body as a subtest over each transport, jsonrpc then amqp, with a client
+// bound to the subtest. A subtest whose endpoint is unreachable is skipped.
+func ForEachTransport(t *testing.T, body func(t *testing.T, client Client)) {
+	for _, transport := range []Transport{JSONRPC, AMQP} {
+		transport := transport
+		t.Run(string(transport), func(t *testing.T) {
+			body(t, ForTest(t, newTransportClient(t, transport)))
+		})
+	}
+}
+
+// ParityError is returned by a ComparingClient when the transports disagree on a call
+type ParityError struct {
+	Method    string
+	Primary   string
+	Secondary string
+}
+
+// Error returns the method and both results
+func (e *ParityError) Error() string {
+	return fmt.Sprintf("%s differs between transports\nprimary:   %s\nsecondary: %s", e.Method, e.Primary, e.Secondary)
+}
+
+// ComparingClient sends read-only calls over both of two clients of the same node, returning a
+// *ParityError when the decoded responses, or the errors, differ. Other calls are only sent to the
+// primary client.
+type ComparingClient struct {
+	primary   Client
+	secondary Client
+}
+
+// NewComparingClient creates a ComparingClient returning the results of primary
+func NewComparingClient(primary Client, secondary Client) *ComparingClient {
+	return &ComparingClient{primary: primary, secondary: secondary}
+}
+
+// Call makes the call over the primary client, and over the secondary client when read-only.
+// Because the chain may advance between the calls, the primary call is repeated once before the
+// results are reported as different.
+func (c *ComparingClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	err := c.primary.Call(ctx, method, params, returnType)
+	if !isReadOnly(method) {
+		return err
+	}
+
+	secondary := returnType.ProtoReflect().New().Interface()
+	secondaryErr := c.secondary.Call(ctx, method, params, secondary)
+	if sameResult(returnType, err, secondary, secondaryErr) {
+		return err
+	}
+
+	proto.Reset(returnType)
+	err = c.primary.Call(ctx, method, params, returnType)
+	if sameResult(returnType, err, secondary, secondaryErr) {
+		return err
+	}
+
+	return &ParityError{Method: method, Primary: parityResult(returnType, err), Secondary: parityResult(secondary, secondaryErr)}
+}
+
+// isReadOnly returns if the method only reads the state of the node
+func isReadOnly(method string) bool {
+	_, name, _ := strings.Cut(method, ".")
+	for _, prefix := range readOnlyPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameResult returns if two calls had equal responses, or chain errors of the same code and
+// message. Other errors are only compared by their presence, as each transport reports them
+// differently.
+func sameResult(a proto.Message, aErr error, b proto.Message, bErr error) bool {
+	if aErr == nil && bErr == nil {
+		return proto.Equal(a, b)
+	}
+
+	if aErr == nil || bErr == nil {
+		return false
+	}
+
+	aChainErr, aOk := AsChainError(aErr)
+	bChainErr, bOk := AsChainError(bErr)
+	if aOk != bOk {
+		return false
+	}
+
+	return !aOk || (aChainErr.Code == bChainErr.Code && aChainErr.Message == bChainErr.Message)
+}
+
+// parityResult renders the result of a call for a ParityError
+func parityResult(response proto.Message, err error) string {
+	if chainErr, ok := AsChainError(err); ok {
+		return fmt.Sprintf("error %d: %s", chainErr.Code, chainErr.Message)
+	}
+
+	if err != nil {
+		return "error: " + err.Error()
+	}
+
+	b, err := protojson.Marshal(response)
+	if err != nil {
+		return "unrenderable response: " + err.Error()
+	}
+
+	return string(b)
+}
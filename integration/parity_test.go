@@ -0,0 +1,104 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"net"
+	"sync"
+	"testing"
+
+	chainrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// driftingClient forwards calls, counting them and adding shift to the head height it reports.
+// The shift grows by drift after each call.
+type driftingClient struct {
+	integration.Client
+	mu    sync.Mutex
+	calls int
+	shift uint64
+	drift uint64
+}
+
+func (c *driftingClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	c.mu.Lock()
+	c.calls++
+	shift := c.shift
+	c.shift += c.drift
+	c.mu.Unlock()
+
+	if err := c.Client.Call(ctx, method, params, returnType); err != nil {
+		return err
+	}
+
+	if head, ok := returnType.(*chainrpc.GetHeadInfoResponse); ok {
+		head.HeadTopology.Height += shift
+	}
+
+	return nil
+}
+
+func TestComparingClient(t *testing.T) {
+	chain := fake.NewClient()
+	primary := &driftingClient{Client: chain}
+	secondary := &driftingClient{Client: chain}
+	client := integration.ForTest(t, integration.NewComparingClient(primary, secondary))
+
+	t.Logf("Comparing read-only calls over both clients")
+	_, err := integration.GetHeadInfo(client)
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.calls)
+	require.Equal(t, 1, secondary.calls)
+
+	t.Logf("Sending other calls to the primary client only")
+	_, err = integration.CreateBlockWith(client, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.calls-secondary.calls)
+
+	t.Logf("Reporting differences in the decoded responses")
+	secondary.shift = 1
+	_, err = integration.GetHeadInfo(client)
+
+	var parityErr *integration.ParityError
+	require.True(t, errors.As(err, &parityErr))
+	require.Equal(t, integration.GetHeadInfoCall, parityErr.Method)
+	require.Regexp(t, `primary: +.*"height": ?"1"`, parityErr.Error())
+	require.Regexp(t, `secondary: .*"height": ?"2"`, parityErr.Error())
+
+	t.Logf("Repeating the primary call when the chain advances between the calls")
+	before := primary.calls
+	primary.drift = 1
+	head, err := integration.GetHeadInfo(client)
+	require.NoError(t, err)
+	require.Equal(t, before+2, primary.calls)
+	require.Equal(t, uint64(2), head.HeadTopology.Height)
+}
+
+func TestForEachTransport(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := fmt.Sprintf("amqp://guest:guest@%s/", closed.Addr())
+	closed.Close()
+
+	t.Setenv(integration.ConnectTimeoutEnv, "100ms")
+	t.Setenv(integration.JSONRPCURLEnv, fmt.Sprintf("http://%s/", listener.Addr()))
+	t.Setenv(integration.AMQPURLEnv, unreachable)
+
+	var ran []string
+	integration.ForEachTransport(t, func(t *testing.T, client integration.Client) {
+		require.NotNil(t, client)
+		ran = append(ran, t.Name())
+	})
+
+	t.Logf("Running the body over each reachable transport")
+	require.Equal(t, []string{"TestForEachTransport/jsonrpc"}, ran)
+}
@@ -0,0 +1,4 @@
+// Package pob wraps the proof of burn contract
+package pob
+
+//go:generate go run ../../cmd/koinos-abigen -abi pob.abi.json -out pob_abi.go
@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+)
+
+// RcPolicy chooses the rc limit of a transaction
+type RcPolicy struct {
+	estimate bool
+	margin   float64
+}
+
+var (
+	// RcMax limits a transaction to all of the payer's available rc. It is the default policy.
+	RcMax = RcPolicy{}
+	// RcExact limits a transaction to the rc it is estimated to use
+	RcExact = RcPolicy{estimate: true, margin: 1}
+)
+
+// RcMargin limits a transaction to the rc it is estimated to use multiplied by margin, such as
+// 1.2 for 20% more than the estimate. The limit is not capped by the payer's available rc.
+func RcMargin(margin float64) RcPolicy {
+	return RcPolicy{estimate: true, margin: margin}
+}
+
+// rcLimit returns the rc limit of a transaction of the operations under the policy
+func (p RcPolicy) rcLimit(client Client, ops []*protocol.Operation, options *transactionOptions, chainID []byte, nonce uint64) (uint64, error) {
+	accountRc, err := GetAccountRc(client, options.payerAddress())
+	if err != nil {
+		return 0, err
+	}
+
+	if !p.estimate {
+		return accountRc, nil
+	}
+
+	trial, err := buildTransaction(ops, options, chainID, nonce, accountRc)
+	if err != nil {
+		return 0, err
+	}
+
+	rcUsed, err := trialRc(client, trial)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(math.Ceil(float64(rcUsed) * p.margin)), nil
+}
+
+// EstimateRc returns the rc used by a transaction of the operations. A trial transaction with the
+// payer's available rc as its limit is submitted without being broadcast, so it never reaches the
+// mempool or a block. At least one signer is required, and the rc limit options are ignored.
+func EstimateRc(client Client, ops []*protocol.Operation, opts ...TransactionOption) (uint64, error) {
+	options := newTransactionOptions(opts)
+	if len(options.signers) == 0 {
+		return 0, fmt.Errorf("expected at least one key")
+	}
+
+	options.rcLimit = nil
+	options.rcPolicy = RcMax
+
+	trial, err := createTransaction(client, ops, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return trialRc(client, trial)
+}
+
+// trialRc submits a transaction without broadcast, returning the rc it used. A reverted
+// transaction still reports the rc it used.
+func trialRc(client Client, transaction *protocol.Transaction) (uint64, error) {
+	receipt, err := submitTransaction(client, transaction, false)
+	if err != nil {
+		return 0, fmt.Errorf("estimating rc: %w", err)
+	}
+
+	return receipt.GetRcUsed(), nil
+}
@@ -0,0 +1,73 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRcPolicy(t *testing.T) {
+	client := fake.NewClient()
+
+	key, err := integration.NewKeyring(t.Name()).Key("alice")
+	require.NoError(t, err)
+
+	ops := []*protocol.Operation{{}, {}, {}}
+
+	t.Logf("Estimating without broadcasting")
+	estimate, err := integration.EstimateRc(client, ops, integration.WithSigner(key), integration.WithRcLimit(1))
+	require.NoError(t, err)
+	require.Equal(t, 3*fake.RcPerOperation, estimate)
+
+	pending, err := integration.GetPendingTransactions(client, 10)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	t.Logf("Choosing the rc limit")
+	for _, c := range []struct {
+		name    string
+		policy  integration.RcPolicy
+		rcLimit uint64
+	}{
+		{"max", integration.RcMax, fake.DefaultRc},
+		{"exact", integration.RcExact, estimate},
+		{"margin", integration.RcMargin(1.5), estimate * 3 / 2},
+	} {
+		transaction, err := integration.CreateTransactionWith(client, ops, integration.WithSigner(key), integration.WithRcPolicy(c.policy))
+		require.NoError(t, err, c.name)
+		require.Equal(t, c.rcLimit, transaction.Header.RcLimit, c.name)
+	}
+
+	transaction, err := integration.CreateTransactionWith(client, ops, integration.WithSigner(key), integration.WithRcPolicy(integration.RcExact))
+	require.NoError(t, err)
+
+	receipt, err := integration.SubmitTransaction(client, transaction)
+	require.NoError(t, err)
+	require.Equal(t, estimate, receipt.RcUsed)
+
+	t.Logf("Rejecting a limit below the estimate")
+	transaction, err = integration.CreateTransactionWith(client, ops, integration.WithSigner(key), integration.WithNonce(2), integration.WithRcLimit(estimate-1))
+	require.NoError(t, err)
+
+	_, err = integration.SubmitTransaction(client, transaction)
+	require.True(t, integration.IsInsufficientRc(err), "expected insufficient rc, was %v", err)
+
+	t.Logf("Estimating each transaction of a builder")
+	builder, err := integration.NewTransactionBuilder(client, integration.WithSigner(key), integration.WithRcPolicy(integration.RcExact))
+	require.NoError(t, err)
+
+	transaction, err = builder.Build(ops[:1])
+	require.NoError(t, err)
+	require.Equal(t, fake.RcPerOperation, transaction.Header.RcLimit)
+
+	transaction, err = builder.Build(ops, integration.WithRcPolicy(integration.RcMax))
+	require.NoError(t, err)
+	require.Equal(t, fake.DefaultRc, transaction.Header.RcLimit)
+	require.EqualValues(t, 3, builder.Nonce())
+
+	_, err = integration.EstimateRc(client, ops)
+	require.Error(t, err)
+}
@@ -0,0 +1,510 @@
+package integration
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	kjson "github.com/koinos/koinos-proto-golang/v2/encoding/json"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	cmsrpc "github.com/koinos/koinos-proto-golang/v2/koinos/rpc/contract_meta_store"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Formats the receipt logging functions can emit
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// ReceiptFormat is the format used by LogBlockReceipt, LogTransactionReceipt and LogBlock.
+// It defaults to the KOINOS_RECEIPT_FORMAT environment variable, or TextFormat.
+var ReceiptFormat = receiptFormatFromEnv()
+
+// DescriptorsFile is the descriptor set resolving the event types of contract ABIs without types
+var DescriptorsFile = "../../node_config/koinos_descriptors.pb"
+
+var (
+	descriptorsOnce sync.Once
+	descriptors     *protoregistry.Files
+
+	entryPointsMu sync.RWMutex
+	entryPoints   = make(map[uint32]string)
+
+	// abiEvents are the event types of each contract registered with RegisterContractABI, by
+	// contract address and event name
+	abiEventsMu sync.RWMutex
+	abiEvents   = make(map[string]map[string]protoreflect.MessageDescriptor)
+)
+
+func receiptFormatFromEnv() string {
+	if format := os.Getenv("KOINOS_RECEIPT_FORMAT"); len(format) > 0 {
+		return format
+	}
+
+	return TextFormat
+}
+
+// LoadDescriptors loads a serialized FileDescriptorSet
+func LoadDescriptors(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(data, set); err != nil {
+		return nil, err
+	}
+
+	return protodesc.NewFiles(set)
+}
+
+// defaultDescriptors lazily loads DescriptorsFile, returning nil if it cannot be loaded
+func defaultDescriptors() *protoregistry.Files {
+	descriptorsOnce.Do(func() {
+		descriptors, _ = LoadDescriptors(DescriptorsFile)
+	})
+
+	return descriptors
+}
+
+// contractABI is the part of a contract ABI describing its events
+type contractABI struct {
+	Events map[string]struct {
+		Argument string `json:"argument"`
+	} `json:"events"`
+
+	// Types is a base64 encoded FileDescriptorSet
+	Types string `json:"types"`
+}
+
+// RegisterContractABI resolves the events of the contract at address for receipt rendering, from
+// its ABI JSON. Each event decodes as the message its ABI names, found in the types of the ABI or
+// in DescriptorsFile.
+func RegisterContractABI(address []byte, abiJSON []byte) error {
+	abi := &contractABI{}
+	if err := json.Unmarshal(abiJSON, abi); err != nil {
+		return fmt.Errorf("contract abi of %s: %w", base58.Encode(address), err)
+	}
+
+	files := defaultDescriptors()
+	if len(abi.Types) > 0 {
+		data, err := base64.StdEncoding.DecodeString(abi.Types)
+		if err != nil {
+			return fmt.Errorf("contract abi types of %s: %w", base58.Encode(address), err)
+		}
+
+		set := &descriptorpb.FileDescriptorSet{}
+		if err = proto.Unmarshal(data, set); err != nil {
+			return fmt.Errorf("contract abi types of %s: %w", base58.Encode(address), err)
+		}
+
+		if files, err = protodesc.NewFiles(set); err != nil {
+			return fmt.Errorf("contract abi types of %s: %w", base58.Encode(address), err)
+		}
+	}
+
+	events := make(map[string]protoreflect.MessageDescriptor, len(abi.Events))
+	for name, event := range abi.Events {
+		if files == nil {
+			return fmt.Errorf("event %s of %s: no descriptors to resolve %s", name, base58.Encode(address), event.Argument)
+		}
+
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(event.Argument))
+		if err != nil {
+			return fmt.Errorf("event %s of %s: %w", name, base58.Encode(address), err)
+		}
+
+		messageDesc, ok := desc.(protoreflect.MessageDescriptor)
+		if !ok {
+			return fmt.Errorf("event %s of %s: %s is not a message", name, base58.Encode(address), event.Argument)
+		}
+
+		events[name] = messageDesc
+	}
+
+	abiEventsMu.Lock()
+	defer abiEventsMu.Unlock()
+
+	abiEvents[string(address)] = events
+
+	return nil
+}
+
+// LoadContractABI registers the ABI the contract at address was uploaded with
+func LoadContractABI(client Client, address []byte) error {
+	ctx, cancel := callContext(client, DefaultTimeout)
+	defer cancel()
+
+	resp := &cmsrpc.GetContractMetaResponse{}
+	if err := client.Call(ctx, GetContractMetaCall, &cmsrpc.GetContractMetaRequest{ContractId: address}, resp); err != nil {
+		return err
+	}
+
+	return RegisterContractABI(address, []byte(resp.GetMeta().GetAbi()))
+}
+
+// abiEventType returns the event type the ABI of the event source names
+func abiEventType(event *protocol.EventData) (protoreflect.MessageDescriptor, bool) {
+	abiEventsMu.RLock()
+	defer abiEventsMu.RUnlock()
+
+	desc, ok := abiEvents[string(event.Source)][event.Name]
+	return desc, ok
+}
+
+// RegisterEntryPoint names an entry point for receipt rendering
+func RegisterEntryPoint(id uint32, name string) {
+	entryPointsMu.Lock()
+	defer entryPointsMu.Unlock()
+
+	entryPoints[id] = name
+}
+
+func entryPointName(id uint32) string {
+	entryPointsMu.RLock()
+	defer entryPointsMu.RUnlock()
+
+	if name, ok := entryPoints[id]; ok {
+		return fmt.Sprintf("%s (0x%08x)", name, id)
+	}
+
+	return fmt.Sprintf("0x%08x", id)
+}
+
+// EventSummary is a rendered event
+type EventSummary struct {
+	Sequence uint32          `json:"sequence"`
+	Name     string          `json:"name"`
+	Source   string          `json:"source"`
+	Impacted []string        `json:"impacted,omitempty"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// OperationSummary is a rendered operation
+type OperationSummary struct {
+	Type       string `json:"type"`
+	Contract   string `json:"contract,omitempty"`
+	EntryPoint string `json:"entry_point,omitempty"`
+	SystemCall string `json:"system_call,omitempty"`
+	Thunk      uint32 `json:"thunk,omitempty"`
+}
+
+// TransactionSummary is a rendered transaction receipt
+type TransactionSummary struct {
+	ID         string              `json:"id"`
+	Payer      string              `json:"payer"`
+	Reverted   bool                `json:"reverted"`
+	RcLimit    uint64              `json:"rc_limit"`
+	RcUsed     uint64              `json:"rc_used"`
+	MaxPayerRc uint64              `json:"max_payer_rc"`
+	Compute    uint64              `json:"compute_bandwidth_used"`
+	Disk       uint64              `json:"disk_storage_used"`
+	Network    uint64              `json:"network_bandwidth_used"`
+	Operations []*OperationSummary `json:"operations,omitempty"`
+	Logs       []string            `json:"logs,omitempty"`
+	Events     []*EventSummary     `json:"events,omitempty"`
+}
+
+// BlockSummary is a rendered block receipt
+type BlockSummary struct {
+	ID              string                `json:"id"`
+	Height          uint64                `json:"height"`
+	Signer          string                `json:"signer,omitempty"`
+	StateMerkleRoot string                `json:"state_merkle_root"`
+	Compute         uint64                `json:"compute_bandwidth_used"`
+	Disk            uint64                `json:"disk_storage_used"`
+	Network         uint64                `json:"network_bandwidth_used"`
+	Logs            []string              `json:"logs,omitempty"`
+	Events          []*EventSummary       `json:"events,omitempty"`
+	Transactions    []*TransactionSummary `json:"transactions,omitempty"`
+}
+
+func hexID(id []byte) string {
+	return "0x" + hex.EncodeToString(id)
+}
+
+// SummarizeEvent decodes an event using the DefaultEventRegistry, falling back to the type the
+// ABI of its source names, see RegisterContractABI. Undecodable data is rendered as base64.
+func SummarizeEvent(event *protocol.EventData) *EventSummary {
+	summary := &EventSummary{
+		Sequence: event.Sequence,
+		Name:     event.Name,
+		Source:   base58.Encode(event.Source),
+	}
+
+	for _, impacted := range event.Impacted {
+		summary.Impacted = append(summary.Impacted, base58.Encode(impacted))
+	}
+
+	summary.Data, _ = json.Marshal(base64.StdEncoding.EncodeToString(event.Data))
+
+	var message proto.Message
+	if decoded, err := DefaultEventRegistry.Decode(event); err == nil && decoded.Message != nil {
+		message = decoded.Message
+	} else if messageDesc, ok := abiEventType(event); ok {
+		dynamic := dynamicpb.NewMessage(messageDesc)
+		if proto.Unmarshal(event.Data, dynamic) == nil {
+			message = dynamic
+		}
+	}
+
+	if message != nil {
+		if data, err := kjson.Marshal(message); err == nil {
+			summary.Data = data
+		}
+	}
+
+	return summary
+}
+
+// SummarizeOperation renders an operation
+func SummarizeOperation(op *protocol.Operation) *OperationSummary {
+	switch o := op.Op.(type) {
+	case *protocol.Operation_UploadContract:
+		return &OperationSummary{Type: "upload_contract", Contract: base58.Encode(o.UploadContract.ContractId)}
+	case *protocol.Operation_CallContract:
+		return &OperationSummary{
+			Type:       "call_contract",
+			Contract:   base58.Encode(o.CallContract.ContractId),
+			EntryPoint: entryPointName(o.CallContract.EntryPoint),
+		}
+	case *protocol.Operation_SetSystemCall:
+		summary := &OperationSummary{
+			Type:       "set_system_call",
+			SystemCall: chain.SystemCallId(o.SetSystemCall.CallId).String(),
+		}
+
+		switch target := o.SetSystemCall.Target.GetTarget().(type) {
+		case *protocol.SystemCallTarget_ThunkId:
+			summary.Thunk = target.ThunkId
+		case *protocol.SystemCallTarget_SystemCallBundle:
+			summary.Contract = base58.Encode(target.SystemCallBundle.ContractId)
+			summary.EntryPoint = entryPointName(target.SystemCallBundle.EntryPoint)
+		}
+
+		return summary
+	case *protocol.Operation_SetSystemContract:
+		return &OperationSummary{
+			Type:     "set_system_contract",
+			Contract: base58.Encode(o.SetSystemContract.ContractId),
+		}
+	}
+
+	return &OperationSummary{Type: "unknown"}
+}
+
+// SummarizeTransactionReceipt renders a transaction receipt. When transaction is not nil, its
+// operations are included.
+func SummarizeTransactionReceipt(receipt *protocol.TransactionReceipt, transaction *protocol.Transaction) *TransactionSummary {
+	summary := &TransactionSummary{
+		ID:         hexID(receipt.Id),
+		Payer:      base58.Encode(receipt.Payer),
+		Reverted:   receipt.Reverted,
+		RcLimit:    receipt.RcLimit,
+		RcUsed:     receipt.RcUsed,
+		MaxPayerRc: receipt.MaxPayerRc,
+		Compute:    receipt.ComputeBandwidthUsed,
+		Disk:       receipt.DiskStorageUsed,
+		Network:    receipt.NetworkBandwidthUsed,
+		Logs:       receipt.Logs,
+	}
+
+	if transaction != nil {
+		for _, op := range transaction.Operations {
+			summary.Operations = append(summary.Operations, SummarizeOperation(op))
+		}
+	}
+
+	for _, event := range receipt.Events {
+		summary.Events = append(summary.Events, SummarizeEvent(event))
+	}
+
+	return summary
+}
+
+// SummarizeBlockReceipt renders a block receipt. When block is not nil, the signer and the
+// operations of each transaction are included.
+func SummarizeBlockReceipt(receipt *protocol.BlockReceipt, block *protocol.Block) *BlockSummary {
+	summary := &BlockSummary{
+		ID:              hexID(receipt.Id),
+		Height:          receipt.Height,
+		StateMerkleRoot: base58.Encode(receipt.StateMerkleRoot),
+		Compute:         receipt.ComputeBandwidthUsed,
+		Disk:            receipt.DiskStorageUsed,
+		Network:         receipt.NetworkBandwidthUsed,
+		Logs:            receipt.Logs,
+	}
+
+	transactions := make(map[string]*protocol.Transaction)
+	if block != nil {
+		summary.Signer = base58.Encode(block.GetHeader().GetSigner())
+		for _, tx := range block.Transactions {
+			transactions[string(tx.Id)] = tx
+		}
+	}
+
+	for _, event := range receipt.Events {
+		summary.Events = append(summary.Events, SummarizeEvent(event))
+	}
+
+	for _, txReceipt := range receipt.TransactionReceipts {
+		summary.Transactions = append(summary.Transactions, SummarizeTransactionReceipt(txReceipt, transactions[string(txReceipt.Id)]))
+	}
+
+	return summary
+}
+
+func (e *EventSummary) text(indent string) []string {
+	lines := []string{fmt.Sprintf("%s- %s from %s: %s", indent, e.Name, e.Source, e.Data)}
+	if len(e.Impacted) > 0 {
+		lines = append(lines, fmt.Sprintf("%s  impacted: %s", indent, strings.Join(e.Impacted, ", ")))
+	}
+
+	return lines
+}
+
+// String renders the operation on a single line
+func (o *OperationSummary) String() string {
+	switch o.Type {
+	case "call_contract":
+		return fmt.Sprintf("call_contract %s %s", o.Contract, o.EntryPoint)
+	case "set_system_call":
+		if len(o.Contract) > 0 {
+			return fmt.Sprintf("set_system_call %s -> %s %s", o.SystemCall, o.Contract, o.EntryPoint)
+		}
+		return fmt.Sprintf("set_system_call %s -> thunk %d", o.SystemCall, o.Thunk)
+	case "unknown":
+		return o.Type
+	}
+
+	return fmt.Sprintf("%s %s", o.Type, o.Contract)
+}
+
+func (s *TransactionSummary) text(prefix string, indent string) []string {
+	reverted := ""
+	if s.Reverted {
+		reverted = " (reverted)"
+	}
+
+	lines := []string{
+		fmt.Sprintf("%sTransaction: %s%s", prefix, s.ID, reverted),
+		fmt.Sprintf("%s* Payer: %s", indent, s.Payer),
+		fmt.Sprintf("%s* RC: %d used, %d limit, %d max", indent, s.RcUsed, s.RcLimit, s.MaxPayerRc),
+		fmt.Sprintf("%s* Compute: %d, Disk: %d, Network: %d", indent, s.Compute, s.Disk, s.Network),
+	}
+
+	if len(s.Operations) > 0 {
+		lines = append(lines, indent+"* Operations")
+		for _, op := range s.Operations {
+			lines = append(lines, fmt.Sprintf("%s - %s", indent, op.String()))
+		}
+	}
+
+	if len(s.Logs) > 0 {
+		lines = append(lines, indent+"* Logs")
+		for _, log := range s.Logs {
+			lines = append(lines, fmt.Sprintf("%s - %s", indent, log))
+		}
+	}
+
+	if len(s.Events) > 0 {
+		lines = append(lines, indent+"* Events")
+		for _, event := range s.Events {
+			lines = append(lines, event.text(indent+" ")...)
+		}
+	}
+
+	return lines
+}
+
+// Lines renders the transaction as text, one line per element
+func (s *TransactionSummary) Lines() []string {
+	return s.text("", " ")
+}
+
+// Lines renders the block as text, one line per element
+func (s *BlockSummary) Lines() []string {
+	lines := []string{fmt.Sprintf("Block: %s (height %d)", s.ID, s.Height)}
+
+	if len(s.Signer) > 0 {
+		lines = append(lines, " * Signer: "+s.Signer)
+	}
+
+	lines = append(lines,
+		" * State merkle root: "+s.StateMerkleRoot,
+		fmt.Sprintf(" * Compute: %d, Disk: %d, Network: %d", s.Compute, s.Disk, s.Network),
+	)
+
+	if len(s.Logs) > 0 {
+		lines = append(lines, " * Logs")
+		for _, log := range s.Logs {
+			lines = append(lines, "  - "+log)
+		}
+	}
+
+	if len(s.Events) > 0 {
+		lines = append(lines, " * Events")
+		for _, event := range s.Events {
+			lines = append(lines, event.text("  ")...)
+		}
+	}
+
+	for _, tx := range s.Transactions {
+		lines = append(lines, tx.text(" > ", "  ")...)
+	}
+
+	return lines
+}
+
+func logSummary(t *testing.T, summary interface{ Lines() []string }) {
+	t.Helper()
+
+	if ReceiptFormat == JSONFormat {
+		data, err := json.Marshal(summary)
+		NoError(t, err)
+		t.Logf(string(data))
+		return
+	}
+
+	for _, line := range summary.Lines() {
+		t.Logf(line)
+	}
+}
+
+// LogBlockReceipt logs a block receipt, including decoded events and log messages
+func LogBlockReceipt(t *testing.T, blockReceipt *protocol.BlockReceipt) {
+	t.Helper()
+	logSummary(t, SummarizeBlockReceipt(blockReceipt, nil))
+}
+
+// LogBlock logs a block receipt along with the signer and operations of the block
+func LogBlock(t *testing.T, block *protocol.Block, blockReceipt *protocol.BlockReceipt) {
+	t.Helper()
+	logSummary(t, SummarizeBlockReceipt(blockReceipt, block))
+}
+
+// LogTransactionReceipt logs a transaction receipt, including decoded events and log messages
+func LogTransactionReceipt(t *testing.T, txReceipt *protocol.TransactionReceipt) {
+	t.Helper()
+	logSummary(t, SummarizeTransactionReceipt(txReceipt, nil))
+}
+
+// LogTransaction logs a transaction receipt along with the operations of the transaction
+func LogTransaction(t *testing.T, transaction *protocol.Transaction, txReceipt *protocol.TransactionReceipt) {
+	t.Helper()
+	logSummary(t, SummarizeTransactionReceipt(txReceipt, transaction))
+}
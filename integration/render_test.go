@@ -0,0 +1,118 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/token"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/governance"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/standards/kcs4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeBlockReceipt(t *testing.T) {
+	integration.DescriptorsFile = "../node_config/koinos_descriptors.pb"
+
+	koinKey, err := integration.GetKey(integration.Koin)
+	require.NoError(t, err)
+	genesisKey, err := integration.GetKey(integration.Genesis)
+	require.NoError(t, err)
+
+	koin := token.GetKoinToken(nil)
+	transfer, err := koin.TransferOperation(genesisKey.AddressBytes(), koinKey.AddressBytes(), 10)
+	require.NoError(t, err)
+
+	override := &protocol.Operation{
+		Op: &protocol.Operation_SetSystemCall{
+			SetSystemCall: &protocol.SetSystemCallOperation{
+				CallId: uint32(chain.SystemCallId_get_account_rc),
+				Target: &protocol.SystemCallTarget{
+					Target: &protocol.SystemCallTarget_SystemCallBundle{
+						SystemCallBundle: &protocol.ContractCallBundle{ContractId: koinKey.AddressBytes(), EntryPoint: token.GetAccountRcEntry},
+					},
+				},
+			},
+		},
+	}
+
+	tx := &protocol.Transaction{Id: []byte{1, 2}, Operations: []*protocol.Operation{transfer, override}}
+	block := &protocol.Block{
+		Header:       &protocol.BlockHeader{Signer: genesisKey.AddressBytes()},
+		Transactions: []*protocol.Transaction{tx},
+	}
+
+	receipt := &protocol.BlockReceipt{
+		Id:     []byte{0xab},
+		Height: 7,
+		Events: []*protocol.EventData{
+			eventData(t, 1, "proposal", genesisKey.AddressBytes(), &governance.ProposalSubmissionEvent{
+				Proposal: &governance.ProposalRecord{VoteThreshold: 5},
+			}),
+			eventData(t, 2, "proposal", koinKey.AddressBytes(), &governance.ProposalSubmissionEvent{}),
+			eventData(t, 3, "koinos.contracts.governance.proposal_record", genesisKey.AddressBytes(), &governance.ProposalRecord{Fee: 1}),
+		},
+		TransactionReceipts: []*protocol.TransactionReceipt{
+			{
+				Id:      tx.Id,
+				Payer:   genesisKey.AddressBytes(),
+				RcLimit: 100,
+				RcUsed:  50,
+				Events: []*protocol.EventData{
+					eventData(t, 0, integration.TokenTransferEvent, koinKey.AddressBytes(), &kcs4.TransferEvent{
+						From:  genesisKey.AddressBytes(),
+						To:    koinKey.AddressBytes(),
+						Value: 10,
+					}),
+				},
+			},
+		},
+	}
+
+	err = integration.RegisterContractABI(genesisKey.AddressBytes(), []byte(`{"events": {"proposal": {"argument": "koinos.contracts.governance.proposal_submission_event"}}}`))
+	require.NoError(t, err)
+
+	err = integration.RegisterContractABI(koinKey.AddressBytes(), []byte(`{"events": {"proposal": {"argument": "koinos.contracts.governance.no_such_event"}}}`))
+	require.Error(t, err)
+
+	summary := integration.SummarizeBlockReceipt(receipt, block)
+	require.Equal(t, "0xab", summary.ID)
+	require.Equal(t, base58.Encode(genesisKey.AddressBytes()), summary.Signer)
+
+	t.Logf("Events decoded through the registry render addresses in base58")
+	txSummary := summary.Transactions[0]
+	require.Equal(t, base58.Encode(genesisKey.AddressBytes()), txSummary.Payer)
+
+	var transferData map[string]interface{}
+	require.NoError(t, json.Unmarshal(txSummary.Events[0].Data, &transferData))
+	require.Equal(t, base58.Encode(genesisKey.AddressBytes()), transferData["from"])
+	require.Equal(t, "10", transferData["value"])
+
+	t.Logf("Events missing from the registry are decoded with the type named by the ABI of their source")
+	var submissionData map[string]interface{}
+	require.NoError(t, json.Unmarshal(summary.Events[0].Data, &submissionData))
+	require.Contains(t, submissionData, "proposal")
+
+	t.Logf("Events are not decoded without an ABI of their source, even when named like a message")
+	var raw string
+	require.NoError(t, json.Unmarshal(summary.Events[1].Data, &raw))
+	require.NoError(t, json.Unmarshal(summary.Events[2].Data, &raw))
+
+	t.Logf("Operations are summarized with entry point names")
+	require.Len(t, txSummary.Operations, 2)
+	require.Equal(t, "transfer (0x27f576ca)", txSummary.Operations[0].EntryPoint)
+	require.Equal(t, "get_account_rc", txSummary.Operations[1].SystemCall)
+
+	text := strings.Join(summary.Lines(), "\n")
+	require.Contains(t, text, "RC: 50 used, 100 limit")
+	require.Contains(t, text, "call_contract "+base58.Encode(koinKey.AddressBytes())+" transfer (0x27f576ca)")
+
+	_, err = json.Marshal(summary)
+	require.NoError(t, err)
+
+	integration.LogBlock(t, block, receipt)
+}
@@ -0,0 +1,4 @@
+// Package resources wraps the resources contract
+package resources
+
+//go:generate go run ../../cmd/koinos-abigen -abi resources.abi.json -out resources_abi.go
@@ -0,0 +1,361 @@
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// Operations supported by a scenario step
+const (
+	MintOp     = "mint"
+	TransferOp = "transfer"
+	BurnOp     = "burn"
+	ApproveOp  = "approve"
+	BalanceOp  = "balance"
+	SupplyOp   = "supply"
+)
+
+// Setup routines that can be requested by a scenario
+const (
+	NameServiceSetup         = "name_service"
+	GetContractMetadataSetup = "get_contract_metadata"
+	ResourcesSetup           = "resources"
+)
+
+// systemKeys maps the names usable in a scenario to the integration system keys
+var systemKeys = map[string]int{
+	"genesis":               integration.Genesis,
+	"governance":            integration.Governance,
+	"koin":                  integration.Koin,
+	"pob":                   integration.Pob,
+	"pob_producer":          integration.PobProducer,
+	"pow":                   integration.Pow,
+	"resources":             integration.Resources,
+	"vhp":                   integration.Vhp,
+	"claim":                 integration.Claim,
+	"claim_delegation":      integration.ClaimDelegation,
+	"name_service":          integration.NameService,
+	"get_contract_metadata": integration.GetContractMetadata,
+}
+
+// Scenario is a declarative description of an integration test
+//
+// Scenarios are written in YAML (or JSON, which is valid YAML):
+//
+//	name: koin
+//	setup: [name_service, get_contract_metadata]
+//	keys: [alice, bob]
+//	contracts:
+//	  - name: koin
+//	    file: ../../contracts/koin.wasm
+//	    key: koin
+//	    system: true
+//	steps:
+//	  - op: mint
+//	    token: koin
+//	    to: alice
+//	    value: 1000
+//	  - op: balance
+//	    token: koin
+//	    account: alice
+//	    value: 1000
+//
+// Keys named in a scenario are either system keys (koin, vhp, genesis...) or keys listed
+// under keys, which are derived from the scenario name and the key name, so every run of a
+// scenario uses the same accounts.
+type Scenario struct {
+	Name      string      `yaml:"name"`
+	Setup     []string    `yaml:"setup"`
+	Keys      []string    `yaml:"keys"`
+	Contracts []*Contract `yaml:"contracts"`
+	Overrides []*Override `yaml:"overrides"`
+	Steps     []*Step     `yaml:"steps"`
+
+	dir string
+}
+
+// Contract is a contract uploaded before the scenario steps run
+type Contract struct {
+	Name   string `yaml:"name"`
+	File   string `yaml:"file"`
+	Key    string `yaml:"key"`
+	System bool   `yaml:"system"`
+}
+
+// Override is a system call override applied before the scenario steps run
+type Override struct {
+	Contract   string `yaml:"contract"`
+	EntryPoint uint32 `yaml:"entry_point"`
+	SystemCall string `yaml:"system_call"`
+}
+
+// Event is an event expected in a step's transaction receipt
+type Event struct {
+	Name   string `yaml:"name"`
+	Source string `yaml:"source"`
+}
+
+// Step is a single token operation or assertion
+type Step struct {
+	Description string `yaml:"description"`
+	Op          string `yaml:"op"`
+	Token       string `yaml:"token"`
+	From        string `yaml:"from"`
+	To          string `yaml:"to"`
+	Account     string `yaml:"account"`
+	Value       uint64 `yaml:"value"`
+
+	// Error is a substring of the error expected when submitting the step
+	Error string `yaml:"error"`
+
+	// Reverted is only checked when set
+	Reverted *bool    `yaml:"reverted"`
+	Events   []*Event `yaml:"events"`
+}
+
+// Load reads a scenario from a file. Contract files are relative to the scenario file.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	s.dir = filepath.Dir(path)
+
+	return s, nil
+}
+
+// Parse parses a YAML or JSON scenario
+func Parse(data []byte) (*Scenario, error) {
+	s := &Scenario{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(s); err != nil {
+		return nil, err
+	}
+
+	for i, step := range s.Steps {
+		switch step.Op {
+		case MintOp, TransferOp, BurnOp, ApproveOp, BalanceOp, SupplyOp:
+		default:
+			return nil, fmt.Errorf("step %d: unknown op %q", i, step.Op)
+		}
+	}
+
+	for _, o := range s.Overrides {
+		if _, ok := chain.SystemCallId_value[o.SystemCall]; !ok {
+			return nil, fmt.Errorf("unknown system call %q", o.SystemCall)
+		}
+	}
+
+	return s, nil
+}
+
+// Runner executes scenarios against a client
+//
+// Setup routines and contract uploads are only applied once per runner, so several
+// scenarios can share a chain.
+type Runner struct {
+	client    integration.Client
+	setup     map[string]struct{}
+	contracts map[string]struct{}
+}
+
+// NewRunner creates a Runner for the client
+func NewRunner(client integration.Client) *Runner {
+	return &Runner{
+		client:    client,
+		setup:     make(map[string]struct{}),
+		contracts: make(map[string]struct{}),
+	}
+}
+
+// Run executes a scenario
+func (r *Runner) Run(t *testing.T, s *Scenario) {
+	// Calls made by this run are bound to the test deadline
+	r = &Runner{client: integration.ForTest(t, r.client), setup: r.setup, contracts: r.contracts}
+
+	keys := make(map[string]*util.KoinosKey)
+	for _, name := range s.Keys {
+		// Keys are derived from the scenario name, so scenarios sharing a chain have distinct accounts
+		key, err := integration.NamedKey(s.Name + "/" + name)
+		integration.NoError(t, err)
+		t.Logf("Key %s: %s", name, base58.Encode(key.AddressBytes()))
+		keys[name] = key
+	}
+
+	getKey := func(name string) *util.KoinosKey {
+		if key, ok := keys[name]; ok {
+			return key
+		}
+
+		if keyType, ok := systemKeys[name]; ok {
+			key, err := integration.GetKey(keyType)
+			integration.NoError(t, err)
+			return key
+		}
+
+		require.FailNow(t, "unknown key", "key %q is not defined by the scenario", name)
+		return nil
+	}
+
+	for _, setup := range s.Setup {
+		if _, ok := r.setup[setup]; ok {
+			continue
+		}
+
+		switch setup {
+		case NameServiceSetup:
+			integration.InitNameService(t, r.client)
+		case GetContractMetadataSetup:
+			integration.InitGetContractMetadata(t, r.client)
+		case ResourcesSetup:
+			integration.InitResources(t, r.client)
+		default:
+			require.FailNow(t, "unknown setup", "setup %q is not supported", setup)
+		}
+
+		r.setup[setup] = struct{}{}
+	}
+
+	for _, c := range s.Contracts {
+		file := c.File
+		if !filepath.IsAbs(file) && len(s.dir) > 0 {
+			file = filepath.Join(s.dir, file)
+		}
+
+		key := getKey(c.Key)
+		id := base58.Encode(key.AddressBytes()) + ":" + file
+		if _, ok := r.contracts[id]; ok {
+			continue
+		}
+
+		t.Logf("Uploading %s contract", c.Name)
+		var err error
+		if c.System {
+			_, err = integration.UploadSystemContract(r.client, file, key, c.Name)
+		} else {
+			err = integration.UploadContract(r.client, file, key)
+		}
+		integration.NoError(t, err)
+
+		r.contracts[id] = struct{}{}
+	}
+
+	for _, o := range s.Overrides {
+		t.Logf("Overriding %s", o.SystemCall)
+		err := integration.SetSystemCallOverride(r.client, getKey(o.Contract), o.EntryPoint, uint32(chain.SystemCallId_value[o.SystemCall]))
+		integration.NoError(t, err)
+	}
+
+	for i, step := range s.Steps {
+		if len(step.Description) > 0 {
+			t.Logf(step.Description)
+		} else {
+			t.Logf("Step %d: %s", i, step.Op)
+		}
+
+		r.runStep(t, step, getKey)
+	}
+}
+
+func (r *Runner) runStep(t *testing.T, step *Step, getKey func(string) *util.KoinosKey) {
+	tokenKey := getKey(step.Token)
+	tkn := token.NewToken(tokenKey.AddressBytes(), r.client)
+
+	var op *protocol.Operation
+	var signer *util.KoinosKey
+	var err error
+
+	switch step.Op {
+	case BalanceOp:
+		balance, err := tkn.Balance(getKey(step.Account).AddressBytes())
+		integration.NoError(t, err)
+		require.EqualValues(t, step.Value, balance, "Unexpected %s balance of %s", step.Token, step.Account)
+		return
+
+	case SupplyOp:
+		supply, err := tkn.TotalSupply()
+		integration.NoError(t, err)
+		require.EqualValues(t, step.Value, supply, "Unexpected %s total supply", step.Token)
+		return
+
+	case MintOp:
+		signer = tokenKey
+		op, err = tkn.MintOperation(getKey(step.To).AddressBytes(), step.Value)
+
+	case TransferOp:
+		signer = getKey(step.From)
+		op, err = tkn.TransferOperation(signer.AddressBytes(), getKey(step.To).AddressBytes(), step.Value)
+
+	case BurnOp:
+		signer = getKey(step.From)
+		op, err = tkn.BurnOperation(signer.AddressBytes(), step.Value)
+
+	case ApproveOp:
+		signer = getKey(step.From)
+		op, err = tkn.ApproveOperation(signer.AddressBytes(), getKey(step.To).AddressBytes(), step.Value)
+	}
+	integration.NoError(t, err)
+
+	receipt, err := r.submit(op, signer)
+	if len(step.Error) > 0 {
+		require.Error(t, err)
+		require.Contains(t, err.Error(), step.Error)
+		return
+	}
+	integration.NoError(t, err)
+
+	require.Len(t, receipt.TransactionReceipts, 1, "Expected 1 transaction receipt")
+	txReceipt := receipt.TransactionReceipts[0]
+
+	if step.Reverted != nil && txReceipt.Reverted != *step.Reverted {
+		integration.LogTransactionReceipt(t, txReceipt)
+		require.Equal(t, *step.Reverted, txReceipt.Reverted, "Unexpected transaction reversion")
+	}
+
+	// Expected events must appear in order, other events may be interleaved
+	next := 0
+	for _, event := range txReceipt.Events {
+		if next == len(step.Events) {
+			break
+		}
+
+		expected := step.Events[next]
+		if event.Name == expected.Name && (len(expected.Source) == 0 || bytes.Equal(event.Source, getKey(expected.Source).AddressBytes())) {
+			next++
+		}
+	}
+
+	if next < len(step.Events) {
+		integration.LogTransactionReceipt(t, txReceipt)
+		require.FailNow(t, "missing event", "expected event %s from %s", step.Events[next].Name, step.Events[next].Source)
+	}
+}
+
+func (r *Runner) submit(op *protocol.Operation, signer *util.KoinosKey) (*protocol.BlockReceipt, error) {
+	transaction, err := integration.CreateTransactionWith(r.client, []*protocol.Operation{op}, integration.WithSigner(signer))
+	if err != nil {
+		return nil, err
+	}
+
+	return integration.CreateBlockWith(r.client, []*protocol.Transaction{transaction})
+}
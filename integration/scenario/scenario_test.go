@@ -0,0 +1,131 @@
+package scenario_test
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"koinos-integration-tests/integration/scenario"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const tokenScenario = `
+name: runner
+keys: [token, alice, bob]
+contracts:
+  - name: token
+    file: token.wasm
+    key: token
+steps:
+  - op: mint
+    token: token
+    to: alice
+    value: 1000
+    reverted: false
+  - op: transfer
+    token: token
+    from: alice
+    to: bob
+    value: 10
+  - op: approve
+    token: token
+    from: alice
+    to: bob
+    value: 5
+  - op: burn
+    token: token
+    from: bob
+    value: 1
+`
+
+func TestParse(t *testing.T) {
+	t.Logf("Parsing a YAML scenario")
+	s, err := scenario.Parse([]byte(tokenScenario))
+	require.NoError(t, err)
+	require.Equal(t, "runner", s.Name)
+	require.Equal(t, []string{"token", "alice", "bob"}, s.Keys)
+	require.Len(t, s.Contracts, 1)
+	require.Equal(t, "token.wasm", s.Contracts[0].File)
+	require.Len(t, s.Steps, 4)
+	require.Equal(t, scenario.TransferOp, s.Steps[1].Op)
+	require.EqualValues(t, 10, s.Steps[1].Value)
+	require.NotNil(t, s.Steps[0].Reverted)
+	require.False(t, *s.Steps[0].Reverted)
+	require.Nil(t, s.Steps[1].Reverted)
+
+	t.Logf("Parsing a JSON scenario")
+	s, err = scenario.Parse([]byte(`{"name": "json", "overrides": [{"contract": "koin", "entry_point": 1, "system_call": "apply_block"}], "steps": [{"op": "supply", "token": "koin", "value": 0}]}`))
+	require.NoError(t, err)
+	require.Equal(t, "json", s.Name)
+	require.Equal(t, "apply_block", s.Overrides[0].SystemCall)
+	require.Equal(t, scenario.SupplyOp, s.Steps[0].Op)
+
+	t.Logf("Rejecting malformed scenarios")
+	_, err = scenario.Parse([]byte("name: [unterminated"))
+	require.Error(t, err)
+
+	_, err = scenario.Parse([]byte("name: unknown field\ntimeout: 10\n"))
+	require.ErrorContains(t, err, "timeout")
+
+	t.Logf("Rejecting unknown steps and system calls")
+	_, err = scenario.Parse([]byte("steps:\n  - op: mint\n  - op: swap\n"))
+	require.EqualError(t, err, `step 1: unknown op "swap"`)
+
+	_, err = scenario.Parse([]byte("overrides:\n  - system_call: no_such_call\n"))
+	require.EqualError(t, err, `unknown system call "no_such_call"`)
+}
+
+func TestRunner(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "runner.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(tokenScenario), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token.wasm"), []byte{0x00, 0x61, 0x73, 0x6d}, 0o644))
+
+	s, err := scenario.Load(path)
+	require.NoError(t, err)
+
+	client := fake.NewClient()
+	runner := scenario.NewRunner(client)
+
+	t.Logf("Uploading the contract and submitting a block per step")
+	runner.Run(t, s)
+	require.Len(t, client.Blocks(), 5)
+
+	key, err := integration.NamedKey("runner/token")
+	require.NoError(t, err)
+	upload := client.Blocks()[0].Transactions[0].Operations[0].GetUploadContract()
+	require.NotNil(t, upload)
+	require.Equal(t, key.AddressBytes(), upload.ContractId)
+
+	for _, block := range client.Blocks()[1:] {
+		require.Len(t, block.Transactions, 1)
+		require.Equal(t, key.AddressBytes(), block.Transactions[0].Operations[0].GetCallContract().GetContractId())
+	}
+
+	t.Logf("Uploading the contract once per runner")
+	runner.Run(t, s)
+	require.Len(t, client.Blocks(), 9)
+
+	t.Logf("Expecting the error of a step")
+	alice, err := integration.NamedKey("errors/alice")
+	require.NoError(t, err)
+	client.SetAccountRc(alice.AddressBytes(), 0)
+
+	s, err = scenario.Parse([]byte(`
+name: errors
+keys: [token, alice, bob]
+steps:
+  - op: transfer
+    token: token
+    from: alice
+    to: bob
+    value: 10
+    error: rc limit
+`))
+	require.NoError(t, err)
+
+	runner.Run(t, s)
+	require.Len(t, client.Blocks(), 9)
+}
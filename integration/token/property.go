@@ -0,0 +1,422 @@
+package token
+
+import (
+	"fmt"
+	"koinos-integration-tests/integration"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// Target is a KCS-4 token under property test. *Token implements it.
+type Target interface {
+	Mint(to []byte, value uint64) error
+	Transfer(from *util.KoinosKey, to []byte, value uint64) error
+	Burn(from *util.KoinosKey, value uint64) error
+	Approve(owner *util.KoinosKey, to []byte, value uint64) error
+	Balance(address []byte) (uint64, error)
+	TotalSupply() (uint64, error)
+	Allowance(owner []byte, spender []byte) (uint64, error)
+}
+
+// OpKind is the kind of a token operation
+type OpKind int
+
+// Token operation kinds
+const (
+	MintOp OpKind = iota
+	TransferOp
+	BurnOp
+	ApproveOp
+	// OverflowOp mints one more token than the total supply can hold
+	OverflowOp
+)
+
+// Op is a token operation on the accounts of a sequence, by index
+type Op struct {
+	Kind OpKind
+	// From is the sender, burner or owner
+	From int
+	// To is the recipient or spender
+	To    int
+	Value uint64
+}
+
+// String returns a description of the operation
+func (o Op) String() string {
+	switch o.Kind {
+	case MintOp:
+		return fmt.Sprintf("mint %d to %d", o.Value, o.To)
+	case TransferOp:
+		return fmt.Sprintf("transfer %d from %d to %d", o.Value, o.From, o.To)
+	case BurnOp:
+		return fmt.Sprintf("burn %d from %d", o.Value, o.From)
+	case ApproveOp:
+		return fmt.Sprintf("approve %d from %d to %d", o.Value, o.From, o.To)
+	case OverflowOp:
+		return fmt.Sprintf("overflow mint to %d", o.To)
+	}
+
+	return fmt.Sprintf("unknown op %d", o.Kind)
+}
+
+// Model is the reference model of a token's balances, allowances and supply
+type Model struct {
+	Supply     uint64
+	Balances   []uint64
+	Allowances map[[2]int]uint64
+}
+
+// NewModel returns a model of accounts with no balances, and the supply held by other accounts
+func NewModel(accounts int, supply uint64) *Model {
+	return &Model{
+		Supply:     supply,
+		Balances:   make([]uint64, accounts),
+		Allowances: make(map[[2]int]uint64),
+	}
+}
+
+// Apply applies the operation to the model, returning false if the token must reject it
+func (m *Model) Apply(op Op) bool {
+	switch op.Kind {
+	case MintOp:
+		if op.Value > math.MaxUint64-m.Supply {
+			return false
+		}
+
+		m.Supply += op.Value
+		m.Balances[op.To] += op.Value
+	case TransferOp:
+		if m.Balances[op.From] < op.Value {
+			return false
+		}
+
+		m.Balances[op.From] -= op.Value
+		m.Balances[op.To] += op.Value
+	case BurnOp:
+		if m.Balances[op.From] < op.Value {
+			return false
+		}
+
+		m.Balances[op.From] -= op.Value
+		m.Supply -= op.Value
+	case ApproveOp:
+		m.Allowances[[2]int{op.From, op.To}] = op.Value
+	case OverflowOp:
+		return false
+	}
+
+	return true
+}
+
+// overflowValue returns the smallest mint overflowing the supply, or false if every mint fits
+func (m *Model) overflowValue() (uint64, bool) {
+	if m.Supply == 0 {
+		return 0, false
+	}
+
+	return math.MaxUint64 - m.Supply + 1, true
+}
+
+// GenerateOps returns n random operations on accounts. The model of the token guides the values,
+// so that sequences mix operations within a balance, of an exact balance and one past a balance.
+func GenerateOps(r *rand.Rand, accounts int, supply uint64, n int) []Op {
+	model := NewModel(accounts, supply)
+	ops := make([]Op, n)
+
+	for i := range ops {
+		from := r.Intn(accounts)
+		op := Op{From: from, To: (from + 1 + r.Intn(accounts-1)) % accounts}
+
+		switch n := r.Intn(11); {
+		case n < 3:
+			op.Kind = MintOp
+			op.Value = 1 + uint64(r.Int63n(1000))
+		case n < 7:
+			op.Kind = TransferOp
+			op.Value = balanceValue(r, model.Balances[from])
+		case n < 9:
+			op.Kind = BurnOp
+			op.Value = balanceValue(r, model.Balances[from])
+		case n < 10:
+			op.Kind = ApproveOp
+			op.Value = 1 + uint64(r.Int63n(1000))
+		default:
+			op.Kind = OverflowOp
+		}
+
+		model.Apply(op)
+		ops[i] = op
+	}
+
+	return ops
+}
+
+// balanceValue returns a value one past the balance, the balance or within the balance
+func balanceValue(r *rand.Rand, balance uint64) uint64 {
+	switch r.Intn(4) {
+	case 0:
+		return balance + 1
+	case 1:
+		if balance > 0 {
+			return balance
+		}
+	}
+
+	if balance <= 1 {
+		return 1
+	}
+
+	return 1 + uint64(r.Int63n(int64(balance)))
+}
+
+// PropertyConfig configures a property test of a token
+type PropertyConfig struct {
+	Seed      int64
+	Sequences int
+	Length    int
+	Accounts  int
+	// MaxShrinks is the most sequences replayed while shrinking a failure
+	MaxShrinks int
+	// Keyring derives the accounts of each sequence, defaulting to a keyring seeded by Seed
+	Keyring *integration.Keyring
+}
+
+// PropertyFailure is a sequence of operations after which a token diverges from its model
+type PropertyFailure struct {
+	Ops     []Op
+	Index   int
+	Message string
+}
+
+// Error returns the divergence and the sequence reproducing it
+func (f *PropertyFailure) Error() string {
+	lines := []string{fmt.Sprintf("after operation %d (%s): %s", f.Index, f.Ops[f.Index], f.Message)}
+	for i, op := range f.Ops {
+		lines = append(lines, fmt.Sprintf("  %d: %s", i, op))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// CheckProperties runs random operation sequences against the target, failing the test with a
+// minimal sequence on which the target diverges from its model
+func CheckProperties(t *testing.T, target Target, cfg PropertyConfig) {
+	t.Helper()
+
+	failure, err := FindFailure(target, cfg)
+	integration.NoError(t, err)
+
+	if failure != nil {
+		require.FailNow(t, "token diverged from its model", "seed %d, %s", cfg.Seed, failure.Error())
+	}
+}
+
+// FindFailure runs random operation sequences against the target, returning the shrunk failure of
+// the first sequence on which it diverges from its model, or nil if none does. Every operation is
+// submitted in its own block, and all balances, allowances and the supply are checked after each.
+func FindFailure(target Target, cfg PropertyConfig) (*PropertyFailure, error) {
+	if cfg.Sequences == 0 {
+		cfg.Sequences = 5
+	}
+	if cfg.Length == 0 {
+		cfg.Length = 20
+	}
+	if cfg.Accounts == 0 {
+		cfg.Accounts = 3
+	}
+	if cfg.MaxShrinks == 0 {
+		cfg.MaxShrinks = 50
+	}
+	if cfg.Keyring == nil {
+		cfg.Keyring = integration.NewKeyring(fmt.Sprintf("kcs4-properties-%d", cfg.Seed))
+	}
+
+	if cfg.Accounts < 2 {
+		return nil, fmt.Errorf("expected at least two accounts")
+	}
+
+	p := &propertyRunner{target: target, cfg: cfg}
+	r := rand.New(rand.NewSource(cfg.Seed))
+
+	for i := 0; i < cfg.Sequences; i++ {
+		supply, err := target.TotalSupply()
+		if err != nil {
+			return nil, err
+		}
+
+		failure, err := p.replay(GenerateOps(r, cfg.Accounts, supply, cfg.Length))
+		if err != nil {
+			return nil, err
+		}
+
+		if failure != nil {
+			return p.shrink(failure), nil
+		}
+	}
+
+	return nil, nil
+}
+
+type propertyRunner struct {
+	target  Target
+	cfg     PropertyConfig
+	replays int
+}
+
+// replay runs the operations on fresh accounts, returning the first divergence from the model
+func (p *propertyRunner) replay(ops []Op) (*PropertyFailure, error) {
+	p.replays++
+
+	keys := make([]*util.KoinosKey, p.cfg.Accounts)
+	for i := range keys {
+		key, err := p.cfg.Keyring.Key(fmt.Sprintf("replay-%d/%d", p.replays, i))
+		if err != nil {
+			return nil, err
+		}
+
+		keys[i] = key
+	}
+
+	supply, err := p.target.TotalSupply()
+	if err != nil {
+		return nil, err
+	}
+
+	model := NewModel(len(keys), supply)
+
+	for i, op := range ops {
+		overflow, canOverflow := model.overflowValue()
+		expected := model.Apply(op)
+
+		var opErr error
+		switch op.Kind {
+		case MintOp:
+			opErr = p.target.Mint(keys[op.To].AddressBytes(), op.Value)
+		case TransferOp:
+			opErr = p.target.Transfer(keys[op.From], keys[op.To].AddressBytes(), op.Value)
+		case BurnOp:
+			opErr = p.target.Burn(keys[op.From], op.Value)
+		case ApproveOp:
+			opErr = p.target.Approve(keys[op.From], keys[op.To].AddressBytes(), op.Value)
+		case OverflowOp:
+			if canOverflow {
+				opErr = p.target.Mint(keys[op.To].AddressBytes(), overflow)
+			}
+		}
+
+		if opErr != nil && expected {
+			return &PropertyFailure{Ops: ops, Index: i, Message: fmt.Sprintf("rejected: %s", opErr)}, nil
+		}
+
+		message, err := p.compare(model, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(message) > 0 {
+			return &PropertyFailure{Ops: ops, Index: i, Message: message}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// compare returns a description of the first difference between the target and the model
+func (p *propertyRunner) compare(model *Model, keys []*util.KoinosKey) (string, error) {
+	supply, err := p.target.TotalSupply()
+	if err != nil {
+		return "", err
+	}
+
+	if supply != model.Supply {
+		return fmt.Sprintf("total supply %d, expected %d", supply, model.Supply), nil
+	}
+
+	for i, key := range keys {
+		balance, err := p.target.Balance(key.AddressBytes())
+		if err != nil {
+			return "", err
+		}
+
+		if balance != model.Balances[i] {
+			return fmt.Sprintf("balance of %d is %d, expected %d", i, balance, model.Balances[i]), nil
+		}
+	}
+
+	for i, owner := range keys {
+		for j, spender := range keys {
+			if i == j {
+				continue
+			}
+
+			allowance, err := p.target.Allowance(owner.AddressBytes(), spender.AddressBytes())
+			if err != nil {
+				return "", err
+			}
+
+			if expected := model.Allowances[[2]int{i, j}]; allowance != expected {
+				return fmt.Sprintf("allowance of %d from %d is %d, expected %d", j, i, allowance, expected), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// shrink removes operations and halves values while the sequence still fails, until neither
+// shrinks it further or the shrink budget is spent
+func (p *propertyRunner) shrink(failure *PropertyFailure) *PropertyFailure {
+	best := failure
+	best.Ops = best.Ops[:best.Index+1]
+	budget := p.cfg.MaxShrinks
+
+	try := func(ops []Op) bool {
+		if budget == 0 {
+			return false
+		}
+		budget--
+
+		f, err := p.replay(ops)
+		if err != nil || f == nil {
+			return false
+		}
+
+		f.Ops = f.Ops[:f.Index+1]
+		best = f
+		return true
+	}
+
+	for shrunk := true; shrunk; {
+		shrunk = false
+
+		for size := len(best.Ops) / 2; size >= 1; size /= 2 {
+			for start := 0; start+size <= len(best.Ops); {
+				candidate := append(append([]Op{}, best.Ops[:start]...), best.Ops[start+size:]...)
+				if len(candidate) > 0 && try(candidate) {
+					shrunk = true
+				} else {
+					start += size
+				}
+			}
+		}
+
+		for i := 0; i < len(best.Ops); i++ {
+			for i < len(best.Ops) && best.Ops[i].Value > 1 {
+				candidate := append([]Op{}, best.Ops...)
+				candidate[i].Value /= 2
+				if !try(candidate) {
+					break
+				}
+
+				shrunk = true
+			}
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,97 @@
+package token_test
+
+import (
+	"fmt"
+	"koinos-integration-tests/integration/token"
+	"math"
+	"math/rand"
+	"testing"
+
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryToken is an in memory KCS-4 token. With burnBug, burning leaves the supply.
+type memoryToken struct {
+	supply     uint64
+	balances   map[string]uint64
+	allowances map[string]uint64
+	burnBug    bool
+}
+
+func newMemoryToken(burnBug bool) *memoryToken {
+	return &memoryToken{balances: make(map[string]uint64), allowances: make(map[string]uint64), burnBug: burnBug}
+}
+
+func (m *memoryToken) Mint(to []byte, value uint64) error {
+	if value > math.MaxUint64-m.supply {
+		return fmt.Errorf("mint would overflow supply")
+	}
+
+	m.supply += value
+	m.balances[string(to)] += value
+	return nil
+}
+
+func (m *memoryToken) Transfer(from *util.KoinosKey, to []byte, value uint64) error {
+	if m.balances[string(from.AddressBytes())] < value {
+		return fmt.Errorf("account 'from' has insufficient balance")
+	}
+
+	m.balances[string(from.AddressBytes())] -= value
+	m.balances[string(to)] += value
+	return nil
+}
+
+func (m *memoryToken) Burn(from *util.KoinosKey, value uint64) error {
+	if m.balances[string(from.AddressBytes())] < value {
+		return fmt.Errorf("account 'from' has insufficient balance")
+	}
+
+	m.balances[string(from.AddressBytes())] -= value
+	if !m.burnBug {
+		m.supply -= value
+	}
+	return nil
+}
+
+func (m *memoryToken) Approve(owner *util.KoinosKey, to []byte, value uint64) error {
+	m.allowances[string(owner.AddressBytes())+string(to)] = value
+	return nil
+}
+
+func (m *memoryToken) Balance(address []byte) (uint64, error) {
+	return m.balances[string(address)], nil
+}
+
+func (m *memoryToken) TotalSupply() (uint64, error) {
+	return m.supply, nil
+}
+
+func (m *memoryToken) Allowance(owner []byte, spender []byte) (uint64, error) {
+	return m.allowances[string(owner)+string(spender)], nil
+}
+
+func TestProperties(t *testing.T) {
+	t.Logf("Generating sequences that exercise every operation")
+	kinds := make(map[token.OpKind]int)
+	for _, op := range token.GenerateOps(rand.New(rand.NewSource(1)), 3, 0, 200) {
+		kinds[op.Kind]++
+	}
+	for _, kind := range []token.OpKind{token.MintOp, token.TransferOp, token.BurnOp, token.ApproveOp, token.OverflowOp} {
+		require.NotZero(t, kinds[kind], "expected %s", token.Op{Kind: kind})
+	}
+
+	t.Logf("Passing a correct token")
+	token.CheckProperties(t, newMemoryToken(false), token.PropertyConfig{Seed: 1, Sequences: 10})
+
+	t.Logf("Shrinking the failure of a buggy token")
+	failure, err := token.FindFailure(newMemoryToken(true), token.PropertyConfig{Seed: 1, Sequences: 10, Length: 30, MaxShrinks: 200})
+	require.NoError(t, err)
+	require.NotNil(t, failure)
+	require.Len(t, failure.Ops, 2, failure.Error())
+	require.Equal(t, token.MintOp, failure.Ops[0].Kind)
+	require.Equal(t, token.BurnOp, failure.Ops[1].Kind)
+	require.EqualValues(t, 1, failure.Ops[0].Value)
+	require.Contains(t, failure.Message, "total supply")
+}
@@ -1,46 +1,56 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"koinos-integration-tests/integration"
+	"strings"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/standards/kcs4"
 	util "github.com/koinos/koinos-util-golang/v2"
-	"google.golang.org/protobuf/proto"
 )
 
-const (
-	mintEntry        uint32 = 0xdc6f17bb
-	balanceOfEntry   uint32 = 0x5c721497
-	totalSupplyEntry uint32 = 0xb0da3934
-	transferEntry    uint32 = 0x27f576ca
-	burnEntry        uint32 = 0x859facc5
-	approveEntry     uint32 = 0x74e21680
-)
+//go:generate go run ../../cmd/koinos-abigen -abi kcs4.abi.json -out kcs4_abi.go
+//go:generate go run ../../cmd/koinos-abigen -abi koin.abi.json -out koin_abi.go
 
 // Token interfaces with a token contract
 type Token struct {
-	key             *util.KoinosKey
-	contractAddress []byte
-	client          integration.Client
+	key      *util.KoinosKey
+	client   integration.Client
+	contract *Kcs4Contract
 }
 
 // NewToken returns a Token object using the contractAddress
 func NewToken(contractAddress []byte, client integration.Client) *Token {
-	return &Token{contractAddress: contractAddress, client: client}
+	return &Token{client: client, contract: NewKcs4Contract(client, contractAddress)}
 }
 
 // GetKoinToken returns the KOIN Token object
 func GetKoinToken(client integration.Client) *Token {
 	koinKey, _ := integration.GetKey(integration.Koin)
-	return &Token{key: koinKey, contractAddress: koinKey.AddressBytes(), client: client}
+	return &Token{key: koinKey, client: client, contract: NewKcs4Contract(client, koinKey.AddressBytes())}
 }
 
 // GetVhpToken returns the VHP Token object
 func GetVhpToken(client integration.Client) *Token {
 	vhpKey, _ := integration.GetKey(integration.Vhp)
-	return &Token{key: vhpKey, contractAddress: vhpKey.AddressBytes(), client: client}
+	return &Token{key: vhpKey, client: client, contract: NewKcs4Contract(client, vhpKey.AddressBytes())}
+}
+
+// WithContext returns a copy of the token whose calls are made within ctx
+func (t *Token) WithContext(ctx context.Context) *Token {
+	return t.withClient(integration.WithContext(t.client, ctx))
+}
+
+func (t *Token) withClient(client integration.Client) *Token {
+	return &Token{key: t.key, client: client, contract: NewKcs4Contract(client, t.contract.Address)}
+}
+
+// Address returns the address of the token contract
+func (t *Token) Address() []byte {
+	return t.contract.Address
 }
 
 // Mint tokens to an address
@@ -49,152 +59,122 @@ func (t *Token) Mint(to []byte, value uint64) error {
 		return fmt.Errorf("token must know key to mint tokens")
 	}
 
-	mintArgs := &kcs4.MintArguments{
-		To:    to,
-		Value: value,
-	}
-
-	args, err := proto.Marshal(mintArgs)
-	if err != nil {
-		return err
-	}
-
-	op := &protocol.Operation{
-		Op: &protocol.Operation_CallContract{
-			CallContract: &protocol.CallContractOperation{
-				ContractId: t.contractAddress,
-				EntryPoint: mintEntry,
-				Args:       args,
-			},
-		},
-	}
-
-	transaction, err := integration.CreateTransaction(t.client, []*protocol.Operation{op}, t.key)
-	if err != nil {
-		return err
-	}
-
-	_, err = integration.CreateBlock(t.client, []*protocol.Transaction{transaction})
+	_, err := t.contract.Mint(&kcs4.MintArguments{To: to, Value: value}, t.key)
 	return err
 }
 
 // Balance of an address
 func (t *Token) Balance(address []byte) (uint64, error) {
-	balance, err := integration.GetAccountBalance(t.client, address, t.contractAddress, balanceOfEntry)
+	balance, err := t.contract.BalanceOf(&kcs4.BalanceOfArguments{Owner: address})
 	if err != nil {
 		return 0, err
 	}
 
-	return balance, nil
+	return balance.GetValue(), nil
 }
 
 // TotalSupply of the token
 func (t *Token) TotalSupply() (uint64, error) {
-	resp, err := integration.ReadContract(t.client, make([]byte, 0), t.contractAddress, totalSupplyEntry)
+	totalSupply, err := t.contract.TotalSupply(&kcs4.TotalSupplyArguments{})
 	if err != nil {
 		return 0, err
 	}
 
-	totalSupply := &kcs4.TotalSupplyResult{}
-	err = proto.Unmarshal(resp.GetResult(), totalSupply)
+	return totalSupply.GetValue(), nil
+}
+
+// Allowance of a spender for an owner's tokens
+func (t *Token) Allowance(owner []byte, spender []byte) (uint64, error) {
+	allowance, err := t.contract.Allowance(&kcs4.AllowanceArguments{Owner: owner, Spender: spender})
 	if err != nil {
 		return 0, err
 	}
 
-	return totalSupply.GetValue(), nil
+	return allowance.GetValue(), nil
 }
 
 // Transfer tokens from one address to another
 func (t *Token) Transfer(from *util.KoinosKey, to []byte, value uint64) error {
-	transferArgs := &kcs4.TransferArguments{
-		From:  from.AddressBytes(),
-		To:    to,
-		Value: value,
-	}
-
-	args, err := proto.Marshal(transferArgs)
-	if err != nil {
-		return err
-	}
-
-	op := &protocol.Operation{
-		Op: &protocol.Operation_CallContract{
-			CallContract: &protocol.CallContractOperation{
-				ContractId: t.contractAddress,
-				EntryPoint: transferEntry,
-				Args:       args,
-			},
-		},
-	}
-
-	transaction, err := integration.CreateTransaction(t.client, []*protocol.Operation{op}, from)
-	if err != nil {
-		return err
-	}
-
-	_, err = integration.CreateBlock(t.client, []*protocol.Transaction{transaction})
+	_, err := t.contract.Transfer(&kcs4.TransferArguments{From: from.AddressBytes(), To: to, Value: value}, from)
 	return err
 }
 
 // Burn tokens from an address
 func (t *Token) Burn(from *util.KoinosKey, value uint64) error {
-	burnArgs := &kcs4.BurnArguments{
-		From:  from.AddressBytes(),
-		Value: value,
-	}
+	_, err := t.contract.Burn(&kcs4.BurnArguments{From: from.AddressBytes(), Value: value}, from)
+	return err
+}
 
-	args, err := proto.Marshal(burnArgs)
-	if err != nil {
-		return err
-	}
+// Approve creates an allowance for the token
+func (t *Token) Approve(owner *util.KoinosKey, to []byte, value uint64) error {
+	_, err := t.contract.Approve(&kcs4.ApproveArguments{Owner: owner.AddressBytes(), Spender: to, Value: value}, owner)
+	return err
+}
 
-	op := &protocol.Operation{
-		Op: &protocol.Operation_CallContract{
-			CallContract: &protocol.CallContractOperation{
-				ContractId: t.contractAddress,
-				EntryPoint: burnEntry,
-				Args:       args,
-			},
-		},
-	}
+// MintOperation returns the operation minting tokens to an address
+func (t *Token) MintOperation(to []byte, value uint64) (*protocol.Operation, error) {
+	return t.contract.MintOperation(&kcs4.MintArguments{
+		To:    to,
+		Value: value,
+	})
+}
 
-	transaction, err := integration.CreateTransaction(t.client, []*protocol.Operation{op}, from)
-	if err != nil {
-		return err
-	}
+// TransferOperation returns the operation transferring tokens from one address to another
+func (t *Token) TransferOperation(from []byte, to []byte, value uint64) (*protocol.Operation, error) {
+	return t.contract.TransferOperation(&kcs4.TransferArguments{
+		From:  from,
+		To:    to,
+		Value: value,
+	})
+}
 
-	_, err = integration.CreateBlock(t.client, []*protocol.Transaction{transaction})
-	return err
+// BurnOperation returns the operation burning tokens from an address
+func (t *Token) BurnOperation(from []byte, value uint64) (*protocol.Operation, error) {
+	return t.contract.BurnOperation(&kcs4.BurnArguments{
+		From:  from,
+		Value: value,
+	})
 }
 
-// Approve creates an allowance for the token
-func (t *Token) Approve(owner *util.KoinosKey, to []byte, value uint64) error {
-	approveArgs := &kcs4.ApproveArguments{
-		Owner:   owner.AddressBytes(),
-		Spender: to,
+// ApproveOperation returns the operation creating an allowance for the token
+func (t *Token) ApproveOperation(owner []byte, spender []byte, value uint64) (*protocol.Operation, error) {
+	return t.contract.ApproveOperation(&kcs4.ApproveArguments{
+		Owner:   owner,
+		Spender: spender,
 		Value:   value,
-	}
-
-	args, err := proto.Marshal(approveArgs)
-	if err != nil {
-		return err
-	}
+	})
+}
 
-	op := &protocol.Operation{
-		Op: &protocol.Operation_CallContract{
-			CallContract: &protocol.CallContractOperation{
-				ContractId: t.contractAddress,
-				EntryPoint: approveEntry,
-				Args:       args,
-			},
+// SupplyInvariant requires the balances of the addresses to sum to the total supply of the token
+// after every block. Every address holding the token must be tracked.
+func (t *Token) SupplyInvariant(addresses ...[]byte) integration.Invariant {
+	return integration.Invariant{
+		Name: "supply",
+		Check: func(check *integration.BlockCheck) error {
+			current := t.withClient(check.Client)
+
+			supply, err := current.TotalSupply()
+			if err != nil {
+				return err
+			}
+
+			var sum uint64
+			balances := make([]string, len(addresses))
+			for i, address := range addresses {
+				balance, err := current.Balance(address)
+				if err != nil {
+					return err
+				}
+
+				sum += balance
+				balances[i] = fmt.Sprintf("%s: %d", base58.Encode(address), balance)
+			}
+
+			if sum != supply {
+				return fmt.Errorf("balances sum to %d, total supply is %d (%s)", sum, supply, strings.Join(balances, ", "))
+			}
+
+			return nil
 		},
 	}
-
-	transaction, err := integration.CreateTransaction(t.client, []*protocol.Operation{op}, owner)
-	if err != nil {
-		return err
-	}
-
-	_, err = integration.CreateBlock(t.client, []*protocol.Transaction{transaction})
-	return err
 }
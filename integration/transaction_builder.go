@@ -0,0 +1,164 @@
+package integration
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+)
+
+// TransactionBuilder creates transactions for an account without querying the chain for each one
+//
+// The chain ID, nonce and rc limit are fetched when the builder is created. Nonces are then
+// assigned locally, so many transactions can be built and signed before any is submitted.
+// When the builder falls out of sync with the chain, such as after a failed submission, Resync
+// fetches the nonce again.
+type TransactionBuilder struct {
+	client  Client
+	options *transactionOptions
+	chainID []byte
+	rcLimit uint64
+
+	mu    sync.Mutex
+	nonce uint64
+}
+
+// NewTransactionBuilder creates a TransactionBuilder. At least one signer is required. The first
+// signer's nonce is tracked, and the options are applied to every transaction built.
+//
+// Without WithRcLimit, every transaction has the payer's rc at creation as its limit. The mempool
+// limits the total rc of an account's pending transactions, so set a lower limit when building
+// many transactions ahead of submission. With an estimating RcPolicy, each transaction is
+// estimated when it is built, which queries the chain.
+func NewTransactionBuilder(client Client, opts ...TransactionOption) (*TransactionBuilder, error) {
+	options := newTransactionOptions(opts)
+	if len(options.signers) == 0 {
+		return nil, fmt.Errorf("expected at least one key")
+	}
+
+	chainID, err := GetChainID(client)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &TransactionBuilder{client: client, options: options, chainID: chainID}
+
+	if options.rcLimit != nil {
+		b.rcLimit = *options.rcLimit
+	} else if !options.rcPolicy.estimate {
+		b.rcLimit, err = GetAccountRc(client, options.payerAddress())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = b.Resync(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Resync sets the nonce from the mempool, including transactions pending for the account
+func (b *TransactionBuilder) Resync() error {
+	nonce, err := GetPendingNonce(b.client, b.options.nonceAccount())
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nonce = nonce
+	return nil
+}
+
+// Nonce returns the nonce of the last transaction built
+func (b *TransactionBuilder) Nonce() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.nonce
+}
+
+// Build creates and signs a transaction with the next nonce. Options are applied after those of
+// the builder. An explicit WithNonce is used as is and does not advance the builder's nonce.
+func (b *TransactionBuilder) Build(ops []*protocol.Operation, opts ...TransactionOption) (*protocol.Transaction, error) {
+	options := *b.options
+	options.signers = append([]*util.KoinosKey(nil), b.options.signers...)
+	options.mutators = append([]func(t *protocol.Transaction) error(nil), b.options.mutators...)
+	options.nonce = nil
+
+	for _, opt := range opts {
+		opt.applyTransaction(&options)
+	}
+
+	if options.nonce != nil {
+		rcLimit, err := b.transactionRcLimit(ops, &options, *options.nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildTransaction(ops, &options, b.chainID, *options.nonce, rcLimit)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rcLimit, err := b.transactionRcLimit(ops, &options, b.nonce+1)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := buildTransaction(ops, &options, b.chainID, b.nonce+1, rcLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	b.nonce++
+	return transaction, nil
+}
+
+// transactionRcLimit returns the rc limit of a transaction, estimating it if the policy requires
+func (b *TransactionBuilder) transactionRcLimit(ops []*protocol.Operation, options *transactionOptions, nonce uint64) (uint64, error) {
+	if options.rcLimit != nil {
+		return *options.rcLimit, nil
+	}
+
+	// A builder created with an estimating policy has no rc limit of its own
+	if options.rcPolicy.estimate || b.options.rcPolicy.estimate {
+		return options.rcPolicy.rcLimit(b.client, ops, options, b.chainID, nonce)
+	}
+
+	return b.rcLimit, nil
+}
+
+// Submit builds and submits a transaction. After a failed submission the builder is resynced,
+// and a transaction rejected for its nonce is rebuilt and submitted once more.
+func (b *TransactionBuilder) Submit(ops []*protocol.Operation, opts ...TransactionOption) (*protocol.TransactionReceipt, error) {
+	receipt, err := b.submit(ops, opts)
+	if err == nil || !IsInvalidNonce(err) {
+		return receipt, err
+	}
+
+	return b.submit(ops, opts)
+}
+
+func (b *TransactionBuilder) submit(ops []*protocol.Operation, opts []TransactionOption) (*protocol.TransactionReceipt, error) {
+	transaction, err := b.Build(ops, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := SubmitTransaction(b.client, transaction)
+	if err != nil {
+		if resyncErr := b.Resync(); resyncErr != nil {
+			return nil, fmt.Errorf("%w, resyncing nonce: %s", err, resyncErr)
+		}
+
+		return nil, err
+	}
+
+	return receipt, nil
+}
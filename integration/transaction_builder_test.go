@@ -0,0 +1,81 @@
+package integration_test
+
+import (
+	"context"
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	util "github.com/koinos/koinos-util-golang/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// countingClient counts the calls made to each method
+type countingClient struct {
+	integration.Client
+	calls map[string]int
+}
+
+func (c *countingClient) Call(ctx context.Context, method string, params proto.Message, returnType proto.Message) error {
+	c.calls[method]++
+	return c.Client.Call(ctx, method, params, returnType)
+}
+
+func TestTransactionBuilder(t *testing.T) {
+	chain := fake.NewClient()
+	client := &countingClient{Client: chain, calls: make(map[string]int)}
+
+	key, err := util.GenerateKoinosKey()
+	require.NoError(t, err)
+
+	builder, err := integration.NewTransactionBuilder(client, integration.WithSigner(key), integration.WithRcLimit(100))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, builder.Nonce())
+
+	t.Logf("Building transactions offline")
+	transactions := make([]*protocol.Transaction, 100)
+	for i := range transactions {
+		transactions[i], err = builder.Build([]*protocol.Operation{})
+		require.NoError(t, err)
+
+		nonce, err := util.NonceBytesToUInt64(transactions[i].Header.Nonce)
+		require.NoError(t, err)
+		require.EqualValues(t, i+1, nonce)
+		require.EqualValues(t, 100, transactions[i].Header.RcLimit)
+	}
+
+	require.Equal(t, map[string]int{integration.GetChainIDCall: 1, integration.GetPendingNonceCall: 1}, client.calls)
+
+	t.Logf("Overriding the nonce")
+	tx, err := builder.Build([]*protocol.Operation{}, integration.WithNonce(1000))
+	require.NoError(t, err)
+	nonce, err := util.NonceBytesToUInt64(tx.Header.Nonce)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, nonce)
+	require.EqualValues(t, 100, builder.Nonce())
+
+	for _, tx := range transactions {
+		_, err = integration.SubmitTransaction(chain, tx)
+		require.NoError(t, err)
+	}
+
+	_, err = integration.CreateBlockWith(chain, transactions)
+	require.NoError(t, err)
+
+	t.Logf("Resyncing after a nonce error")
+	external, err := integration.CreateTransactionWith(chain, []*protocol.Operation{}, integration.WithSigner(key))
+	require.NoError(t, err)
+	_, err = integration.SubmitTransaction(chain, external)
+	require.NoError(t, err)
+
+	_, err = builder.Submit([]*protocol.Operation{})
+	require.NoError(t, err)
+	require.EqualValues(t, 102, builder.Nonce())
+	require.Equal(t, 2, client.calls[integration.GetPendingNonceCall])
+
+	pendingNonce, err := integration.GetPendingNonce(chain, key.AddressBytes())
+	require.NoError(t, err)
+	require.EqualValues(t, 102, pendingNonce)
+}
@@ -0,0 +1,288 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	defaultWaitTimeout          = 2 * time.Minute
+	defaultWaitInterval         = 100 * time.Millisecond
+	defaultWaitMaxInterval      = 2 * time.Second
+	defaultWaitProgressInterval = 5 * time.Second
+
+	// blockScanBatch is how many blocks TransactionIncluded requests at once
+	blockScanBatch = 100
+	// diagnosticPendingLimit is how many pending transactions are listed when a wait times out
+	diagnosticPendingLimit = 10
+)
+
+// Condition is what WaitFor waits for
+type Condition struct {
+	// Client is the node the condition queries. When set, its head and pending transactions are
+	// logged if the wait times out.
+	Client Client
+	// Check returns if the condition holds, and a description of the current state for progress logs
+	Check func() (done bool, status string, err error)
+}
+
+type waitOptions struct {
+	timeout          time.Duration
+	interval         time.Duration
+	maxInterval      time.Duration
+	progressInterval time.Duration
+}
+
+// WaitOption configures WaitFor
+type WaitOption func(o *waitOptions)
+
+// WithWaitTimeout bounds the wait, within the test deadline, instead of two minutes
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithBackoff checks the condition again after interval once the first check does not hold,
+// doubling the delay between checks up to maxInterval
+func WithBackoff(interval time.Duration, maxInterval time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.interval = interval
+		o.maxInterval = maxInterval
+	}
+}
+
+// WithProgressInterval sets how often the state of the condition is logged while waiting
+func WithProgressInterval(interval time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.progressInterval = interval
+	}
+}
+
+// WaitFor checks condition with backoff until it holds, logging its progress. When ctx ends or the
+// timeout passes, shortly before the test deadline at the latest, the test fails with the last
+// state of the condition and the head and pending transactions of its node. Errors of the check
+// are retried.
+func WaitFor(ctx context.Context, t *testing.T, description string, condition Condition, opts ...WaitOption) {
+	t.Helper()
+
+	status, err := wait(ctx, t, description, condition, opts)
+	if err == nil {
+		return
+	}
+
+	message := "last state: " + status
+	if condition.Client != nil {
+		message += "\n" + diagnostics(condition.Client)
+	}
+
+	require.FailNow(t, fmt.Sprintf("waiting for %s: %s", description, err), message)
+}
+
+// wait checks the condition until it holds, returning its last state and why it stopped otherwise
+func wait(ctx context.Context, t *testing.T, description string, condition Condition, opts []WaitOption) (string, error) {
+	options := &waitOptions{
+		timeout:          defaultWaitTimeout,
+		interval:         defaultWaitInterval,
+		maxInterval:      defaultWaitMaxInterval,
+		progressInterval: defaultWaitProgressInterval,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	start := time.Now()
+	deadline := testDeadline(t, options.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	interval := options.interval
+	lastProgress := start
+
+	for {
+		done, status, err := condition.Check()
+		if err != nil {
+			status = "error: " + err.Error()
+		} else if done {
+			return status, nil
+		}
+
+		now := time.Now()
+		if !now.Before(deadline) {
+			return status, fmt.Errorf("timed out after %s", now.Sub(start).Round(time.Millisecond))
+		}
+
+		if now.Sub(lastProgress) >= options.progressInterval {
+			t.Logf("Waiting for %s: %s (%s elapsed)", description, status, now.Sub(start).Round(time.Second))
+			lastProgress = now
+		}
+
+		delay := interval
+		if remaining := time.Until(deadline); remaining < delay {
+			delay = remaining
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return status, ctx.Err()
+		}
+
+		if interval *= 2; interval > options.maxInterval {
+			interval = options.maxInterval
+		}
+	}
+}
+
+// diagnostics describes the head and pending transactions of the node
+func diagnostics(client Client) string {
+	lines := make([]string, 0, diagnosticPendingLimit+2)
+
+	headInfo, err := GetHeadInfo(client)
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("head: %s", err))
+	} else {
+		lines = append(lines, fmt.Sprintf("head: height %d, id 0x%s, last irreversible block %d", headInfo.HeadTopology.GetHeight(), hex.EncodeToString(headInfo.HeadTopology.GetId()), headInfo.LastIrreversibleBlock))
+	}
+
+	pending, err := GetPendingTransactions(client, diagnosticPendingLimit)
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("pending transactions: %s", err))
+	} else {
+		lines = append(lines, fmt.Sprintf("pending transactions: %d shown, up to %d", len(pending), diagnosticPendingLimit))
+		for _, p := range pending {
+			lines = append(lines, fmt.Sprintf("  0x%s", hex.EncodeToString(p.Transaction.GetId())))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// HeightAtLeast holds once the head of the node is at height or above
+func HeightAtLeast(client Client, height uint64) Condition {
+	return Condition{
+		Client: client,
+		Check: func() (bool, string, error) {
+			headInfo, err := GetHeadInfo(client)
+			if err != nil {
+				return false, "", err
+			}
+
+			current := headInfo.HeadTopology.GetHeight()
+			return current >= height, fmt.Sprintf("head height %d of %d", current, height), nil
+		},
+	}
+}
+
+// TransactionIncluded holds once the transaction with id is in a block on the head branch of the
+// node. Blocks are scanned from the first block, then each new block once. When the head branch
+// changes, the blocks it no longer holds are scanned again from the last common ancestor.
+func TransactionIncluded(client Client, id []byte) Condition {
+	// scanned holds the ids of the scanned blocks, indexed by height - 1
+	var scanned [][]byte
+
+	return Condition{
+		Client: client,
+		Check: func() (bool, string, error) {
+			headInfo, err := GetHeadInfo(client)
+			if err != nil {
+				return false, "", err
+			}
+
+			head := headInfo.HeadTopology
+			if scanned, err = commonAncestor(client, head, scanned); err != nil {
+				return false, "", err
+			}
+
+			for uint64(len(scanned)) < head.Height {
+				from := uint64(len(scanned)) + 1
+				count := head.Height - uint64(len(scanned))
+				if count > blockScanBatch {
+					count = blockScanBatch
+				}
+
+				blocks, err := GetBlocksByHeight(client, head.Id, from, uint32(count), false, true)
+				if err != nil {
+					return false, "", err
+				}
+
+				if len(blocks.BlockItems) == 0 {
+					return false, "", fmt.Errorf("no blocks from height %d", from)
+				}
+
+				for _, item := range blocks.BlockItems {
+					for _, receipt := range item.Receipt.GetTransactionReceipts() {
+						if bytes.Equal(receipt.Id, id) {
+							return true, fmt.Sprintf("included at height %d", item.BlockHeight), nil
+						}
+					}
+
+					scanned = append(scanned, item.BlockId)
+				}
+			}
+
+			return false, fmt.Sprintf("not included up to height %d", len(scanned)), nil
+		},
+	}
+}
+
+// commonAncestor truncates the ids of the scanned blocks, indexed by height - 1, to the blocks
+// still on the branch of head
+func commonAncestor(client Client, head *koinos.BlockTopology, scanned [][]byte) ([][]byte, error) {
+	if uint64(len(scanned)) > head.Height {
+		scanned = scanned[:head.Height]
+	}
+
+	for len(scanned) > 0 {
+		to := uint64(len(scanned))
+		from := uint64(1)
+		if to > blockScanBatch {
+			from = to - blockScanBatch + 1
+		}
+
+		blocks, err := GetBlocksByHeight(client, head.Id, from, uint32(to-from+1), false, false)
+		if err != nil {
+			return nil, err
+		}
+
+		items := blocks.BlockItems
+		for i := len(items) - 1; i >= 0; i-- {
+			height := items[i].BlockHeight
+			if height >= from && height <= to && bytes.Equal(items[i].BlockId, scanned[height-1]) {
+				return scanned[:height], nil
+			}
+		}
+
+		scanned = scanned[:from-1]
+	}
+
+	return scanned, nil
+}
+
+// MempoolSize holds once the mempool of the node holds exactly size transactions
+func MempoolSize(client Client, size int) Condition {
+	return Condition{
+		Client: client,
+		Check: func() (bool, string, error) {
+			pending, err := GetPendingTransactions(client, uint64(size)+1)
+			if err != nil {
+				return false, "", err
+			}
+
+			if len(pending) > size {
+				return false, fmt.Sprintf("more than %d pending transactions", size), nil
+			}
+
+			return len(pending) == size, fmt.Sprintf("%d of %d pending transactions", len(pending), size), nil
+		},
+	}
+}
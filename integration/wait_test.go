@@ -0,0 +1,80 @@
+package integration_test
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/fake"
+	"testing"
+	"time"
+
+	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitFor(t *testing.T) {
+	client := integration.ForTest(t, fake.NewClient())
+	ctx := integration.TestContext(t)
+	fast := integration.WithBackoff(time.Millisecond, 10*time.Millisecond)
+
+	key, err := integration.NewKeyring(t.Name()).Key("alice")
+	require.NoError(t, err)
+
+	t.Logf("Waiting for blocks produced while waiting")
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(5 * time.Millisecond)
+			integration.CreateBlockWith(client, nil)
+		}
+	}()
+
+	integration.WaitFor(ctx, t, "height 3", integration.HeightAtLeast(client, 3), fast)
+
+	t.Logf("Waiting for pending transactions")
+	transaction, err := integration.CreateTransactionWith(client, []*protocol.Operation{{}}, integration.WithSigner(key))
+	require.NoError(t, err)
+
+	_, err = integration.SubmitTransaction(client, transaction)
+	require.NoError(t, err)
+
+	integration.WaitFor(ctx, t, "one pending transaction", integration.MempoolSize(client, 1), fast)
+
+	t.Logf("Waiting for a transaction to be included")
+	condition := integration.TransactionIncluded(client, transaction.Id)
+	done, status, err := condition.Check()
+	require.NoError(t, err)
+	require.False(t, done)
+	require.Equal(t, "not included up to height 3", status)
+
+	included, err := integration.CreateBlockWith(client, []*protocol.Transaction{transaction})
+	require.NoError(t, err)
+	_, err = integration.CreateBlockWith(client, nil)
+	require.NoError(t, err)
+
+	integration.WaitFor(ctx, t, "the transaction", condition, fast)
+
+	done, status, err = condition.Check()
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Equal(t, "included at height 4", status)
+
+	integration.WaitFor(ctx, t, "an empty mempool", integration.MempoolSize(client, 0), fast)
+
+	t.Logf("Scanning the blocks of a new head branch from the last common ancestor")
+	transaction, err = integration.CreateTransactionWith(client, []*protocol.Operation{{}}, integration.WithSigner(key))
+	require.NoError(t, err)
+
+	condition = integration.TransactionIncluded(client, transaction.Id)
+	done, status, err = condition.Check()
+	require.NoError(t, err)
+	require.False(t, done)
+	require.Equal(t, "not included up to height 5", status)
+
+	fork, err := integration.CreateBlockWith(client, []*protocol.Transaction{transaction}, integration.WithParent(included))
+	require.NoError(t, err)
+	_, err = integration.CreateBlockWith(client, nil, integration.WithParent(fork))
+	require.NoError(t, err)
+
+	done, status, err = condition.Check()
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Equal(t, "included at height 5", status)
+}
@@ -6,23 +6,21 @@ import (
 
 	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
-	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/stretchr/testify/require"
 )
 
 func TestAddThunk(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
+	client := integration.NewClientFromEnv(t)
 
 	genesisKey, err := integration.GetKey(integration.Genesis)
 	integration.NoError(t, err)
 
 	t.Logf("Generating key for add_thunk contract")
-	addThunkKey, err := util.GenerateKoinosKey()
+	addThunkKey, err := integration.NamedKey("add_thunk")
 	integration.NoError(t, err)
 
 	t.Logf("Generating key for call_nop contract")
-	callNopKey, err := util.GenerateKoinosKey()
+	callNopKey, err := integration.NamedKey("call_nop")
 	integration.NoError(t, err)
 
 	require.NotEqualValues(t, addThunkKey, callNopKey)
@@ -96,12 +94,7 @@ func TestAddThunk(t *testing.T) {
 		},
 	}
 
-	modRcLimit := func(t *protocol.Transaction) error {
-		t.Header.RcLimit = maxRc / 4
-		return nil
-	}
-
-	tx, err = integration.CreateTransaction(client, []*protocol.Operation{setSystemContract, callAddThunk, overrideNop}, genesisKey, modRcLimit)
+	tx, err = integration.CreateTransactionWith(client, []*protocol.Operation{setSystemContract, callAddThunk, overrideNop}, integration.WithSigner(genesisKey), integration.WithRcLimit(maxRc/4))
 	integration.NoError(t, err)
 
 	_, err = integration.SubmitTransaction(client, tx)
@@ -112,7 +105,7 @@ func TestAddThunk(t *testing.T) {
 
 	t.Logf("Calling call_nop again")
 
-	tx, err = integration.CreateTransaction(client, []*protocol.Operation{callContract}, genesisKey, modRcLimit)
+	tx, err = integration.CreateTransactionWith(client, []*protocol.Operation{callContract}, integration.WithSigner(genesisKey), integration.WithRcLimit(maxRc/4))
 	integration.NoError(t, err)
 
 	_, err = integration.SubmitTransaction(client, tx)
@@ -120,7 +113,7 @@ func TestAddThunk(t *testing.T) {
 
 	t.Logf("Check error when running add_thunk again")
 
-	tx, err = integration.CreateTransaction(client, []*protocol.Operation{callAddThunk}, genesisKey, modRcLimit)
+	tx, err = integration.CreateTransactionWith(client, []*protocol.Operation{callAddThunk}, integration.WithSigner(genesisKey), integration.WithRcLimit(maxRc/4))
 	integration.NoError(t, err)
 
 	_, err = integration.SubmitTransaction(client, tx)
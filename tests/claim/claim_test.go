@@ -2,13 +2,12 @@ package claim
 
 import (
 	"encoding/hex"
+	"koinos-integration-tests/integration/bootstrap"
 	claimUtil "koinos-integration-tests/integration/claim"
-	"koinos-integration-tests/integration/token"
 
 	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/claim"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
 	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/stretchr/testify/require"
 
 	"koinos-integration-tests/integration"
@@ -32,28 +31,10 @@ const (
 )
 
 func TestClaim(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
+	client := integration.NewClientFromEnv(t)
 
-	claimKey, err := integration.GetKey(integration.Claim)
-	integration.NoError(t, err)
-
-	koinKey, err := integration.GetKey(integration.Koin)
-	integration.NoError(t, err)
-
-	integration.AwaitChain(t, client)
-
-	integration.InitNameService(t, client)
-	integration.InitGetContractMetadata(t, client)
-
-	t.Logf("Uploading KOIN contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/koin.wasm", koinKey, "koin")
-	integration.NoError(t, err)
-
-	t.Logf("Uploading claim contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/claim.wasm", claimKey, "claim")
-	integration.NoError(t, err)
-
-	cl := claimUtil.NewClaim(client)
+	c := bootstrap.New(client).WithClaim().Apply(t)
+	cl := c.Claim
 
 	t.Logf("Checking initial info")
 	info := &claim.ClaimInfo{
@@ -64,15 +45,15 @@ func TestClaim(t *testing.T) {
 	}
 	testInfo(t, cl, info)
 
-	aliceKey, err := util.GenerateKoinosKey()
+	aliceKey, err := integration.NamedKey("alice")
 	integration.NoError(t, err)
 	aliceAddress := aliceKey.AddressBytes()
 
-	bobKey, err := util.GenerateKoinosKey()
+	bobKey, err := integration.NamedKey("bob")
 	integration.NoError(t, err)
 	bobAddress := bobKey.AddressBytes()
 
-	koin := token.GetKoinToken(client)
+	koin := c.Koin
 
 	totalSupply, err := koin.TotalSupply()
 	integration.NoError(t, err)
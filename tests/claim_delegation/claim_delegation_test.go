@@ -3,8 +3,8 @@ package claim_delegation
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"koinos-integration-tests/integration/bootstrap"
 	claimUtil "koinos-integration-tests/integration/claim"
-	"koinos-integration-tests/integration/name_service"
 	"koinos-integration-tests/integration/token"
 
 	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
@@ -12,7 +12,6 @@ import (
 	tokenproto "github.com/koinos/koinos-proto-golang/v2/koinos/contracts/token"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
 	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/stretchr/testify/require"
 
 	"koinos-integration-tests/integration"
@@ -38,13 +37,7 @@ const (
 )
 
 func TestClaimDelegation(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
-
-	claimKey, err := integration.GetKey(integration.Claim)
-	integration.NoError(t, err)
-
-	koinKey, err := integration.GetKey(integration.Koin)
-	integration.NoError(t, err)
+	client := integration.NewClientFromEnv(t)
 
 	governanceKey, err := integration.GetKey(integration.Governance)
 	integration.NoError(t, err)
@@ -52,24 +45,12 @@ func TestClaimDelegation(t *testing.T) {
 	claimDelegationKey, err := integration.GetKey(integration.ClaimDelegation)
 	integration.NoError(t, err)
 
-	integration.AwaitChain(t, client)
-
-	integration.InitNameService(t, client)
-	integration.InitGetContractMetadata(t, client)
-
-	t.Logf("Uploading KOIN contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/koin.wasm", koinKey, "koin")
-	integration.NoError(t, err)
-
-	t.Logf("Uploading claim contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/claim.wasm", claimKey, "claim")
-	integration.NoError(t, err)
-
-	ns := name_service.GetNameService(client)
-	genesisKey, err := integration.GetKey(integration.Genesis)
-	integration.NoError(t, err)
+	c := bootstrap.New(client).WithClaim().Apply(t)
+	claimKey := c.Key(integration.Claim)
+	koinKey := c.Key(integration.Koin)
+	genesisKey := c.Genesis
 
-	_, err = ns.SetRecord(t, genesisKey, "governance", governanceKey.AddressBytes())
+	_, err = c.NameService.SetRecord(t, genesisKey, "governance", governanceKey.AddressBytes())
 	integration.NoError(t, err)
 
 	t.Logf("Claim contract: %v\n", base64.StdEncoding.EncodeToString(claimKey.AddressBytes()))
@@ -88,13 +69,13 @@ func TestClaimDelegation(t *testing.T) {
 	)
 	integration.NoError(t, err)
 
-	koin := token.GetKoinToken(client)
+	koin := c.Koin
 
-	aliceKey, err := util.GenerateKoinosKey()
+	aliceKey, err := integration.NamedKey("alice")
 	integration.NoError(t, err)
 	aliceAddress := aliceKey.AddressBytes()
 
-	bobKey, err := util.GenerateKoinosKey()
+	bobKey, err := integration.NamedKey("bob")
 	integration.NoError(t, err)
 	bobAddress := bobKey.AddressBytes()
 
@@ -118,10 +99,10 @@ func TestClaimDelegation(t *testing.T) {
 
 	checkSupply(t, koin, expectedSupply)
 
-	err = integration.SetSystemCallOverride(client, koinKey, uint32(0x2d464aab), uint32(chain.SystemCallId_get_account_rc))
+	err = integration.SetSystemCallOverride(client, koinKey, token.GetAccountRcEntry, uint32(chain.SystemCallId_get_account_rc))
 	integration.NoError(t, err)
 
-	cl := claimUtil.NewClaim(client)
+	cl := c.Claim
 
 	t.Logf("Checking initial info")
 	info := &claim.ClaimInfo{
@@ -340,7 +321,6 @@ func checkSupply(t *testing.T, koin *token.Token, expected uint64) {
 }
 
 func transferWithWrongKey(client integration.Client, koinKey *util.KoinosKey, signerKey *util.KoinosKey, from []byte, to []byte, value uint64) error {
-	const transferEntry uint32 = 0x27f576ca
 	transferArgs := &tokenproto.TransferArguments{
 		From:  from,
 		To:    to,
@@ -356,7 +336,7 @@ func transferWithWrongKey(client integration.Client, koinKey *util.KoinosKey, si
 		Op: &protocol.Operation_CallContract{
 			CallContract: &protocol.CallContractOperation{
 				ContractId: koinKey.AddressBytes(),
-				EntryPoint: transferEntry,
+				EntryPoint: token.TransferEntry,
 				Args:       args,
 			},
 		},
@@ -4,28 +4,24 @@ import (
 	"github.com/koinos/koinos-proto-golang/v2/koinos/canonical"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
-	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/stretchr/testify/require"
 
 	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/bootstrap"
 	"testing"
 )
 
 func TestError(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
+	client := integration.NewClientFromEnv(t)
 
 	genesisKey, err := integration.GetKey(integration.Genesis)
 	integration.NoError(t, err)
 
 	t.Logf("Generating key for exit contract")
-	exitKey, err := util.GenerateKoinosKey()
+	exitKey, err := integration.NamedKey("exit")
 	integration.NoError(t, err)
 
-	integration.AwaitChain(t, client)
-
-	integration.InitNameService(t, client)
-	integration.InitGetContractMetadata(t, client)
+	bootstrap.New(client).WithGetContractMetadata().Apply(t)
 
 	t.Logf("Uploading exit contract")
 	_, err = integration.UploadSystemContract(client, "../../contracts/exit.wasm", exitKey, "exit")
@@ -63,6 +59,7 @@ func TestError(t *testing.T) {
 	require.Error(t, err)
 
 	t.Logf("Ensuring the error message was propagated to the response")
+	require.True(t, integration.IsReversion(err), "Expected a reversion, was %v", err)
 	require.EqualValues(t, "a reversion has occurred", err.Error(), "Unexpected error message")
 
 	t.Logf("Calling exit contract with failure")
@@ -97,5 +94,7 @@ func TestError(t *testing.T) {
 	require.Error(t, err)
 
 	t.Logf("Ensuring the error message was propagated to the response")
+	_, ok := integration.AsChainError(err)
+	require.True(t, ok, "Expected a chain error, was %v", err)
 	require.EqualValues(t, "a failure has occurred", err.Error(), "Unexpected error message")
 }
@@ -7,15 +7,14 @@ import (
 
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
 	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/stretchr/testify/require"
 )
 
 func TestFailures(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
+	client := integration.NewClientFromEnv(t)
 
 	t.Logf("Generating key for failures")
-	failuresKey, err := util.GenerateKoinosKey()
+	failuresKey, err := integration.NamedKey("failures")
 	integration.NoError(t, err)
 
 	integration.AwaitChain(t, client)
@@ -35,7 +34,7 @@ func TestFailures(t *testing.T) {
 }
 
 func testEntryPoint(t *testing.T, client integration.Client, key *util.KoinosKey, entryPoint uint32) (*protocol.TransactionReceipt, error) {
-	aliceKey, err := util.GenerateKoinosKey()
+	aliceKey, err := integration.NamedKey("alice/" + strconv.FormatUint(uint64(entryPoint), 10))
 	integration.NoError(t, err)
 
 	op := &protocol.Operation{
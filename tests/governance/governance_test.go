@@ -2,6 +2,7 @@ package governance
 
 import (
 	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/bootstrap"
 	govUtil "koinos-integration-tests/integration/governance"
 	"koinos-integration-tests/integration/token"
 	"strconv"
@@ -10,10 +11,7 @@ import (
 	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/governance"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
-	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -33,47 +31,18 @@ const (
 )
 
 func TestGovernance(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
+	client := integration.NewClientFromEnv(t)
 
-	genesisKey, err := integration.GetKey(integration.Genesis)
-	integration.NoError(t, err)
-
-	governanceKey, err := integration.GetKey(integration.Governance)
-	integration.NoError(t, err)
-
-	koinKey, err := integration.GetKey(integration.Koin)
-	integration.NoError(t, err)
-
-	integration.AwaitChain(t, client)
+	c := bootstrap.New(client).WithGovernance().Apply(t)
+	genesisKey := c.Genesis
+	koinKey := c.Key(integration.Koin)
 
-	integration.InitNameService(t, client)
-	integration.InitGetContractMetadata(t, client)
-
-	t.Logf("Uploading KOIN contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/koin.wasm", koinKey, "koin")
-	integration.NoError(t, err)
-
-	t.Logf("Uploading governance contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/governance.wasm", governanceKey, "governance", func(op *protocol.UploadContractOperation) error {
-		op.AuthorizesTransactionApplication = true
-		return nil
-	})
-	integration.NoError(t, err)
-
-	maxRc, err := integration.GetAccountRc(client, governanceKey.AddressBytes())
+	maxRc, err := integration.GetAccountRc(client, c.Key(integration.Governance).AddressBytes())
 	integration.NoError(t, err)
 	t.Logf("Governance max RC: %d", maxRc)
 
-	t.Logf("Overriding pre_block system call")
-	err = integration.SetSystemCallOverride(client, governanceKey, uint32(0x531d5d4e), uint32(chain.SystemCallId_pre_block_callback))
-	integration.NoError(t, err)
-
-	t.Logf("Overriding check_system_authority system call")
-	err = integration.SetSystemCallOverride(client, governanceKey, uint32(0xa88d06c9), uint32(chain.SystemCallId_check_system_authority))
-	integration.NoError(t, err)
-
 	t.Logf("Overriding get_account_rc system call")
-	err = integration.SetSystemCallOverride(client, koinKey, uint32(0x2d464aab), uint32(chain.SystemCallId_get_account_rc))
+	err = integration.SetSystemCallOverride(client, koinKey, token.GetAccountRcEntry, uint32(chain.SystemCallId_get_account_rc))
 	integration.NoError(t, err)
 
 	t.Logf("Pushing block to ensure pre_block system call does not halt chain")
@@ -95,7 +64,7 @@ func testProposalFees(t *testing.T, client integration.Client) {
 	koin := token.GetKoinToken(client)
 	gov := govUtil.GetGovernance(client)
 
-	aliceKey, err := util.GenerateKoinosKey()
+	aliceKey, err := integration.NamedKey("alice/fees")
 	integration.NoError(t, err)
 
 	totalSupply, err := koin.TotalSupply()
@@ -164,7 +133,7 @@ func testSuccessfulProposal(t *testing.T, client integration.Client, proposalFac
 		threshold = GovernanceThreshold
 	}
 
-	aliceKey, err := util.GenerateKoinosKey()
+	aliceKey, err := integration.NamedKey("alice/successful/" + strconv.Itoa(proposalType))
 	integration.NoError(t, err)
 
 	genesisKey, err := integration.GetKey(integration.Genesis)
@@ -249,13 +218,8 @@ func testSuccessfulProposal(t *testing.T, client integration.Client, proposalFac
 	require.EqualValues(t, 1, len(blockEvents), "Expected 1 event within the block receipt")
 	require.EqualValues(t, "koinos.contracts.governance.proposal_status_event", blockEvents[0].Name, "Expected 'koinos.contracts.governance.proposal_status_event' event in block receipt")
 
-	var statusEvent governance.ProposalStatusEvent
-	err = proto.Unmarshal(blockEvents[0].Data, &statusEvent)
-	integration.NoError(t, err)
-
 	t.Logf("Ensuring the correct proposal status was emitted")
-	require.EqualValues(t, statusEvent.Id, mroot, "Proposal ID mismatch")
-	require.EqualValues(t, statusEvent.Status, governance.ProposalStatus_active, "Proposal status mismatch")
+	integration.RequireEvent(t, receipt, &governance.ProposalStatusEvent{Id: mroot, Status: governance.ProposalStatus_active})
 
 	t.Logf("Querying proposals")
 	proposals, err = gov.GetProposals()
@@ -325,12 +289,8 @@ func testSuccessfulProposal(t *testing.T, client integration.Client, proposalFac
 	require.EqualValues(t, 1, len(blockEvents), "Expected 1 event within the block receipt")
 	require.EqualValues(t, "koinos.contracts.governance.proposal_status_event", blockEvents[0].Name, "Expected 'koinos.contracts.governance.proposal_status_event' event in block receipt")
 
-	err = proto.Unmarshal(blockEvents[0].Data, &statusEvent)
-	integration.NoError(t, err)
-
 	t.Logf("Ensuring the correct proposal status was emitted")
-	require.EqualValues(t, statusEvent.Id, mroot, "Proposal ID mismatch")
-	require.EqualValues(t, statusEvent.Status, governance.ProposalStatus_approved, "Proposal status mismatch")
+	integration.RequireEvent(t, receipt, &governance.ProposalStatusEvent{Id: mroot, Status: governance.ProposalStatus_approved})
 
 	t.Logf("Querying proposals")
 	proposals, err = gov.GetProposals()
@@ -383,12 +343,8 @@ func testSuccessfulProposal(t *testing.T, client integration.Client, proposalFac
 	require.EqualValues(t, 1, len(receipt.Events), "Expected 3 event within the block receipt")
 	require.EqualValues(t, "koinos.contracts.governance.proposal_status_event", receipt.Events[0].Name, "Expected 'koinos.contracts.governance.proposal_status_event' event in block receipt")
 
-	err = proto.Unmarshal(receipt.Events[0].Data, &statusEvent)
-	integration.NoError(t, err)
-
 	t.Logf("Ensuring the correct proposal status was emitted")
-	require.EqualValues(t, statusEvent.Id, mroot, "Proposal ID mismatch")
-	require.EqualValues(t, statusEvent.Status, governance.ProposalStatus_applied, "Proposal status mismatch")
+	integration.RequireEvent(t, receipt, &governance.ProposalStatusEvent{Id: mroot, Status: governance.ProposalStatus_applied})
 
 	err = onSuccess(client, t)
 	require.Nil(t, err)
@@ -402,7 +358,7 @@ func testFailedProposal(t *testing.T, client integration.Client, proposalFactory
 		threshold = GovernanceThreshold
 	}
 
-	aliceKey, err := util.GenerateKoinosKey()
+	aliceKey, err := integration.NamedKey("alice/failed/" + strconv.Itoa(proposalType))
 	integration.NoError(t, err)
 
 	genesisKey, err := integration.GetKey(integration.Genesis)
@@ -487,13 +443,8 @@ func testFailedProposal(t *testing.T, client integration.Client, proposalFactory
 	require.EqualValues(t, 1, len(blockEvents), "Expected 1 event within the block receipt")
 	require.EqualValues(t, "koinos.contracts.governance.proposal_status_event", blockEvents[0].Name, "Expected 'koinos.contracts.governance.proposal_status_event' event in block receipt")
 
-	var statusEvent governance.ProposalStatusEvent
-	err = proto.Unmarshal(blockEvents[0].Data, &statusEvent)
-	integration.NoError(t, err)
-
 	t.Logf("Ensuring the correct proposal status was emitted")
-	require.EqualValues(t, statusEvent.Id, mroot, "Proposal ID mismatch")
-	require.EqualValues(t, statusEvent.Status, governance.ProposalStatus_active, "Proposal status mismatch")
+	integration.RequireEvent(t, receipt, &governance.ProposalStatusEvent{Id: mroot, Status: governance.ProposalStatus_active})
 
 	t.Logf("Querying proposals")
 	proposals, err = gov.GetProposals()
@@ -564,12 +515,8 @@ func testFailedProposal(t *testing.T, client integration.Client, proposalFactory
 	require.EqualValues(t, 1, len(blockEvents), "Expected 1 event within the block receipt")
 	require.EqualValues(t, "koinos.contracts.governance.proposal_status_event", blockEvents[0].Name, "Expected 'koinos.contracts.governance.proposal_status_event' event in block receipt")
 
-	err = proto.Unmarshal(blockEvents[0].Data, &statusEvent)
-	integration.NoError(t, err)
-
 	t.Logf("Ensuring the correct proposal status was emitted")
-	require.EqualValues(t, statusEvent.Id, mroot, "Proposal ID mismatch")
-	require.EqualValues(t, statusEvent.Status, governance.ProposalStatus_expired, "Proposal status mismatch")
+	integration.RequireEvent(t, receipt, &governance.ProposalStatusEvent{Id: mroot, Status: governance.ProposalStatus_expired})
 
 	t.Logf("Querying proposals")
 	proposals, err = gov.GetProposals()
@@ -595,7 +542,7 @@ func testLogOverrideProposal(client integration.Client, t *testing.T) error {
 	integration.NoError(t, err)
 
 	t.Logf("Generating key for hello contract")
-	helloKey, err := util.GenerateKoinosKey()
+	helloKey, err := integration.NamedKey("hello")
 	integration.NoError(t, err)
 
 	t.Logf("Creating and uploading hello contract")
@@ -608,7 +555,7 @@ func testLogOverrideProposal(client integration.Client, t *testing.T) error {
 	integration.LogBlockReceipt(t, receipt)
 
 	t.Logf("Generating key for bob")
-	bobKey, err := util.GenerateKoinosKey()
+	bobKey, err := integration.NamedKey("bob")
 	integration.NoError(t, err)
 
 	callContract := &protocol.Operation{
@@ -638,7 +585,7 @@ func testLogOverrideProposal(client integration.Client, t *testing.T) error {
 }
 
 func makeLogOverrideProposal(t *testing.T, client integration.Client) ([]byte, []*protocol.Operation, error) {
-	syscallOverrideKey, err := util.GenerateKoinosKey()
+	syscallOverrideKey, err := integration.NamedKey("syscall_override")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7,12 +7,11 @@ import (
 	nameUtil "koinos-integration-tests/integration/name_service"
 
 	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/stretchr/testify/require"
 )
 
 func TestNameService(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
+	client := integration.NewClientFromEnv(t)
 
 	genesisKey, err := integration.GetKey(integration.Genesis)
 	integration.NoError(t, err)
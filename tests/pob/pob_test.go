@@ -1,74 +1,32 @@
 package pob
 
 import (
-	"bytes"
-	"context"
 	"koinos-integration-tests/integration"
-	"koinos-integration-tests/integration/name_service"
-	"koinos-integration-tests/integration/token"
+	"koinos-integration-tests/integration/bootstrap"
+	pob_contract "koinos-integration-tests/integration/pob"
 	"testing"
 	"time"
 
 	"github.com/koinos/koinos-proto-golang/v2/koinos/chain"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/contracts/pob"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
-	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
+	"github.com/koinos/koinos-proto-golang/v2/koinos/standards/kcs4"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
 
-const (
-	burnEntry                  uint32 = 0x859facc5
-	registerPublicKeyEntry            = 0x53192be1
-	processBlockSignatureEntry        = 0xe0adbeab
-)
-
 func TestPob(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
-
-	koinKey, err := integration.GetKey(integration.Koin)
-	integration.NoError(t, err)
-
-	vhpKey, err := integration.GetKey(integration.Vhp)
-	integration.NoError(t, err)
-
-	pobKey, err := integration.GetKey(integration.Pob)
-	integration.NoError(t, err)
+	client := integration.NewClientFromEnv(t)
 
 	producerKey, err := integration.GetKey(integration.PobProducer)
 	integration.NoError(t, err)
 
-	genesisKey, err := integration.GetKey(integration.Genesis)
-	integration.NoError(t, err)
-
-	koin := token.GetKoinToken(client)
-	vhp := token.GetVhpToken(client)
+	c := bootstrap.New(client).WithPob().Apply(t)
 
-	integration.AwaitChain(t, client)
-
-	integration.InitNameService(t, client)
-	integration.InitGetContractMetadata(t, client)
-
-	t.Logf("Uploading KOIN contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/koin.wasm", koinKey, "koin")
-	integration.NoError(t, err)
-
-	t.Logf("Uploading VHP contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/vhp.wasm", vhpKey, "vhp")
-	integration.NoError(t, err)
-
-	t.Logf("Uploading PoB contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/pob.wasm", pobKey, "pob")
-	integration.NoError(t, err)
-
-	nameService := name_service.GetNameService(client)
-
-	_, err = nameService.SetRecord(t, genesisKey, "vhp", vhpKey.AddressBytes())
-	integration.NoError(t, err)
-
-	_, err = nameService.SetRecord(t, genesisKey, "pob", pobKey.AddressBytes())
-	integration.NoError(t, err)
+	pobKey := c.Key(integration.Pob)
+	genesisKey := c.Genesis
+	koin := c.Koin
+	vhp := c.Vhp
 
 	t.Logf("Minting KOIN")
 	koin.Mint(producerKey.AddressBytes(), 100000000000000) // 1,000,000.00000000 KOIN
@@ -94,7 +52,7 @@ func TestPob(t *testing.T) {
 		Op: &protocol.Operation_CallContract{
 			CallContract: &protocol.CallContractOperation{
 				ContractId: pobKey.AddressBytes(),
-				EntryPoint: burnEntry,
+				EntryPoint: pob_contract.BurnEntry,
 				Args:       args,
 			},
 		},
@@ -109,7 +67,8 @@ func TestPob(t *testing.T) {
 	require.EqualValues(t, len(receipt.TransactionReceipts), 1, "Expected 1 transaction receipt")
 	require.EqualValues(t, len(receipt.TransactionReceipts[0].Events), 2, "Expected 2 events in transaction receipt")
 
-	// TODO: Check events
+	integration.RequireEvent(t, receipt, &kcs4.BurnEvent{From: producerKey.AddressBytes(), Value: burnArgs.TokenAmount}, koin.Address())
+	integration.RequireEvent(t, receipt, &kcs4.MintEvent{To: producerKey.AddressBytes(), Value: burnArgs.TokenAmount}, vhp.Address())
 
 	producerBalance, err = koin.Balance(producerKey.AddressBytes())
 	integration.NoError(t, err)
@@ -132,7 +91,7 @@ func TestPob(t *testing.T) {
 		Op: &protocol.Operation_CallContract{
 			CallContract: &protocol.CallContractOperation{
 				ContractId: pobKey.AddressBytes(),
-				EntryPoint: registerPublicKeyEntry,
+				EntryPoint: pob_contract.RegisterPublicKeyEntry,
 				Args:       args,
 			},
 		},
@@ -148,12 +107,10 @@ func TestPob(t *testing.T) {
 	require.EqualValues(t, 1, len(receipt.TransactionReceipts[0].Events), "Expected 1 events in transaction receipt")
 	require.EqualValues(t, pobKey.AddressBytes(), receipt.TransactionReceipts[0].Events[0].Source, "Unexpected event source on register public key event")
 
-	registerPublicKeyEvent := &pob.RegisterPublicKeyEvent{}
-	err = proto.Unmarshal(receipt.TransactionReceipts[0].Events[0].Data, registerPublicKeyEvent)
-	integration.NoError(t, err)
-
-	require.EqualValues(t, producerKey.AddressBytes(), registerPublicKeyEvent.Address, "Unexpected address on register public key event")
-	require.EqualValues(t, producerKey.PublicBytes(), registerPublicKeyEvent.PublicKey, "Unexpected public key on register public key event")
+	integration.RequireEvent(t, receipt, &pob.RegisterPublicKeyEvent{
+		Address:   producerKey.AddressBytes(),
+		PublicKey: producerKey.PublicBytes(),
+	}, pobKey.AddressBytes())
 
 	integration.CreateBlocks(client, 20, genesisKey)
 
@@ -167,7 +124,7 @@ func TestPob(t *testing.T) {
 					Target: &protocol.SystemCallTarget_SystemCallBundle{
 						SystemCallBundle: &protocol.ContractCallBundle{
 							ContractId: pobKey.AddressBytes(),
-							EntryPoint: processBlockSignatureEntry,
+							EntryPoint: pob_contract.ProcessBlockSignatureEntry,
 						},
 					},
 				},
@@ -186,76 +143,24 @@ func TestPob(t *testing.T) {
 
 	endBlock := headInfo.HeadTopology.Height + 10
 
-	test_timer := time.NewTimer(30 * time.Second)
-	cancelChan := make(chan struct{})
-	go func() {
-		select {
-		case <-cancelChan:
-			return
-		case <-test_timer.C:
-			panic("Timer expired")
-		}
-	}()
-
-	for {
-		headInfo, err = integration.GetHeadInfo(client)
-		integration.NoError(t, err)
-
-		t.Logf("Block Height %d", headInfo.HeadTopology.Height)
-
-		if headInfo.HeadTopology.Height > endBlock {
-			cancelChan <- struct{}{}
-			break
-		}
-
-		time.Sleep(time.Second)
-	}
+	ctx := integration.TestContext(t)
+	integration.WaitFor(ctx, t, "block production", integration.HeightAtLeast(client, endBlock+1), integration.WithWaitTimeout(30*time.Second))
 
-	headInfo, err = integration.GetHeadInfo(client)
+	// Set the public key again. Should trigger key delay
+	tx, err = integration.CreateTransactionWith(client, []*protocol.Operation{registerKey}, integration.WithSigner(producerKey), integration.WithNonce(4))
 	integration.NoError(t, err)
 
-	headBlockNum := headInfo.HeadTopology.Height
-
-	// Set the public key again. Should trigger key delay
-	txReceipt, err := client.SubmitTransaction(context.Background(), []*protocol.Operation{registerKey}, producerKey, &kjsonrpc.SubmissionParams{Nonce: 4, RCLimit: 0}, true)
+	txReceipt, err := integration.SubmitTransaction(client, tx)
 	integration.NoError(t, err)
 
 	require.EqualValues(t, len(txReceipt.Events), 1, "Expected 1 events in transaction receipt")
 
-	trxIncluded := false
-
-	// Check every block until the transaction is included
-	for !trxIncluded {
-		headInfo, err = integration.GetHeadInfo(client)
-		integration.NoError(t, err)
-
-		if headInfo.HeadTopology.Height > headBlockNum {
-			req := &block_store.GetBlocksByHeightRequest{
-				HeadBlockId:         headInfo.HeadTopology.Id,
-				AncestorStartHeight: headBlockNum + 1,
-				NumBlocks:           uint32(headInfo.HeadTopology.Height) - uint32(headBlockNum),
-				ReturnReceipt:       true,
-			}
-			resp := &block_store.GetBlocksByHeightResponse{}
-
-			err := client.Call(context.Background(), "block_store.get_blocks_by_height", req, resp)
-			integration.NoError(t, err)
-
-			headBlockNum = headInfo.HeadTopology.Height
-
-			for _, blockItem := range resp.BlockItems {
-				for _, receipt := range blockItem.Receipt.TransactionReceipts {
-					if bytes.Equal(receipt.Id, txReceipt.Id) {
-						t.Logf("Transaction included in block %d", headBlockNum)
-						trxIncluded = true
-						break
-					}
-				}
-			}
-
-			time.Sleep(time.Second)
-		}
-	}
+	integration.WaitFor(ctx, t, "the key registration", integration.TransactionIncluded(client, txReceipt.Id))
+
+	headInfo, err = integration.GetHeadInfo(client)
+	integration.NoError(t, err)
+
+	headBlockNum := headInfo.HeadTopology.Height
 
 	<-(time.NewTimer(5 * time.Second).C)
 	headInfo, err = integration.GetHeadInfo(client)
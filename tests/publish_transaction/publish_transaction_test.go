@@ -2,82 +2,30 @@ package publishTransaction
 
 import (
 	"bytes"
-	"context"
-	"encoding/json"
+	"koinos-integration-tests/integration"
 	"testing"
 	"time"
 
-	kjson "github.com/koinos/koinos-proto-golang/v2/encoding/json"
 	"github.com/koinos/koinos-proto-golang/v2/koinos/protocol"
-	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/block_store"
-	"github.com/koinos/koinos-proto-golang/v2/koinos/rpc/chain"
-	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
-	jsonrpc "github.com/ybbus/jsonrpc/v3"
 )
 
 func TestPublishTransaction(t *testing.T) {
-	rpcClient := jsonrpc.NewClient("http://localhost:28080/")
+	client := integration.NewClientFromEnv(t)
+	ctx := integration.TestContext(t)
 
-	headInfoResponse := chain.GetHeadInfoResponse{}
+	integration.AwaitChain(t, client)
+	integration.WaitFor(ctx, t, "the first block", integration.HeightAtLeast(client, 1), integration.WithWaitTimeout(45*time.Second))
 
-	for {
-		response, err := rpcClient.Call(context.Background(), "chain.get_head_info", json.RawMessage("{}"))
-		if err == nil && response.Error == nil {
-			raw := json.RawMessage{}
-			err := response.GetObject(&raw)
-			if err != nil {
-				t.Error(err)
-			}
+	headInfo, err := integration.GetHeadInfo(client)
+	integration.NoError(t, err)
 
-			err = kjson.Unmarshal([]byte(raw), &headInfoResponse)
-			if err != nil {
-				t.Error(err)
-			}
+	startingBlock := headInfo.HeadTopology.Height
 
-			break
-		}
-
-		time.Sleep(time.Second)
-	}
-
-	test_timer := time.NewTimer(45 * time.Second)
-	go func() {
-		<-test_timer.C
-		panic("Timer expired")
-	}()
-
-	for {
-		response, err := rpcClient.Call(context.Background(), "chain.get_head_info", json.RawMessage("{}"))
-		if err == nil && response.Error == nil {
-			raw := json.RawMessage{}
-			err := response.GetObject(&raw)
-			if err != nil {
-				t.Error(err)
-			}
-
-			err = kjson.Unmarshal([]byte(raw), &headInfoResponse)
-			if err != nil {
-				t.Error(err)
-			}
-
-			if headInfoResponse.HeadTopology.Height > 0 {
-				break
-			}
-		}
-
-		time.Sleep(time.Second)
-	}
-
-	startingBlock := headInfoResponse.HeadTopology.Height
-
-	key, err := util.GenerateKoinosKey()
+	key, err := integration.NamedKey("contract")
 	if err != nil {
 		t.Error(err)
 	}
 
-	krpc := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
-
 	ops := make([]*protocol.Operation, 1)
 	ops[0] = &protocol.Operation{
 		Op: &protocol.Operation_UploadContract{
@@ -87,66 +35,19 @@ func TestPublishTransaction(t *testing.T) {
 		},
 	}
 
-	txReceipt, err := krpc.SubmitTransaction(context.Background(), ops, key, &kjsonrpc.SubmissionParams{Nonce: 0, RCLimit: 0}, true)
-
-	if err != nil {
-		t.Error(err)
-	}
-
-	currentHeight := headInfoResponse.HeadTopology.Height
-
-	for currentHeight <= startingBlock {
-		response, err := rpcClient.Call(context.Background(), "chain.get_head_info", json.RawMessage("{}"))
-		if err == nil {
-			raw := json.RawMessage{}
-			err := response.GetObject(&raw)
-			if err != nil {
-				t.Error(err)
-			}
-
-			err = kjson.Unmarshal([]byte(raw), &headInfoResponse)
-			if err != nil {
-				t.Error(err)
-			}
-
-			currentHeight = headInfoResponse.HeadTopology.Height
-		}
-
-		time.Sleep(time.Second)
-	}
-
-	getBlocksByHeightRequest := block_store.GetBlocksByHeightRequest{
-		HeadBlockId:         headInfoResponse.HeadTopology.Id,
-		AncestorStartHeight: startingBlock + 1,
-		NumBlocks:           1,
-		ReturnBlock:         true,
-		ReturnReceipt:       false,
-	}
-	blocksReq, err := kjson.Marshal(&getBlocksByHeightRequest)
-	if err != nil {
-		t.Error(err)
-	}
+	tx, err := integration.CreateTransactionWith(client, ops, integration.WithSigner(key))
+	integration.NoError(t, err)
 
-	response, err := rpcClient.Call(context.Background(), "block_store.get_blocks_by_height", json.RawMessage(blocksReq))
-	if err != nil {
-		t.Error(err)
-	}
+	txReceipt, err := integration.SubmitTransaction(client, tx)
+	integration.NoError(t, err)
 
-	if response.Error != nil {
-		t.Error(response.Error)
-	}
+	integration.WaitFor(ctx, t, "the next block", integration.HeightAtLeast(client, startingBlock+1))
 
-	getBlocksByHeightResponse := &block_store.GetBlocksByHeightResponse{}
-	raw := json.RawMessage{}
-	err = response.GetObject(&raw)
-	if err != nil {
-		t.Error(err)
-	}
+	headInfo, err = integration.GetHeadInfo(client)
+	integration.NoError(t, err)
 
-	err = kjson.Unmarshal([]byte(raw), getBlocksByHeightResponse)
-	if err != nil {
-		t.Error(err)
-	}
+	getBlocksByHeightResponse, err := integration.GetBlocksByHeight(client, headInfo.HeadTopology.Id, startingBlock+1, 1, true, false)
+	integration.NoError(t, err)
 
 	if getBlocksByHeightResponse.BlockItems == nil || len(getBlocksByHeightResponse.BlockItems) != 1 {
 		t.Errorf("Expected 1 block item, was %v", len(getBlocksByHeightResponse.BlockItems))
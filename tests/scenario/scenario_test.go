@@ -0,0 +1,31 @@
+package scenario
+
+import (
+	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/scenario"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarios(t *testing.T) {
+	client := integration.NewClientFromEnv(t)
+
+	files, err := filepath.Glob("scenarios/*.yaml")
+	integration.NoError(t, err)
+	require.NotEmpty(t, files, "No scenario files found")
+
+	integration.AwaitChain(t, client)
+
+	runner := scenario.NewRunner(client)
+
+	for _, file := range files {
+		s, err := scenario.Load(file)
+		integration.NoError(t, err)
+
+		t.Run(s.Name, func(t *testing.T) {
+			runner.Run(t, s)
+		})
+	}
+}
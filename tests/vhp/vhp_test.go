@@ -2,42 +2,30 @@ package vhp
 
 import (
 	"koinos-integration-tests/integration"
+	"koinos-integration-tests/integration/bootstrap"
 	"koinos-integration-tests/integration/token"
 	"math"
 	"testing"
 
-	util "github.com/koinos/koinos-util-golang/v2"
-	kjsonrpc "github.com/koinos/koinos-util-golang/v2/rpc"
 	"github.com/stretchr/testify/require"
 )
 
 func TestVhp(t *testing.T) {
-	client := kjsonrpc.NewKoinosRPCClient("http://localhost:28080/")
+	client := integration.NewClientFromEnv(t)
 
 	t.Logf("Generating key for alice")
-	aliceKey, err := util.GenerateKoinosKey()
+	aliceKey, err := integration.NamedKey("alice")
 	integration.NoError(t, err)
 
 	t.Logf("Generating key for bob")
-	bobKey, err := util.GenerateKoinosKey()
+	bobKey, err := integration.NamedKey("bob")
 	integration.NoError(t, err)
 
 	require.NotEqualValues(t, aliceKey, bobKey)
 
-	vhpKey, err := integration.GetKey(integration.Vhp)
-	integration.NoError(t, err)
-
-	integration.AwaitChain(t, client)
-
-	integration.InitNameService(t, client)
-	integration.InitGetContractMetadata(t, client)
-
-	t.Logf("Uploading VHP contract")
-	_, err = integration.UploadSystemContract(client, "../../contracts/vhp.wasm", vhpKey, "vhp")
-	integration.NoError(t, err)
+	vhp := bootstrap.New(client).WithVhp().Apply(t).Vhp
 
 	t.Logf("Minting 1000 satoshis to alice")
-	vhp := token.GetVhpToken(client)
 	err = vhp.Mint(aliceKey.AddressBytes(), uint64(1000))
 	integration.NoError(t, err)
 
@@ -142,4 +130,8 @@ func TestVhp(t *testing.T) {
 	integration.NoError(t, err)
 
 	require.EqualValues(t, uint64(900), bobBalance)
+
+	t.Run("Properties", func(t *testing.T) {
+		token.CheckProperties(t, vhp, token.PropertyConfig{Seed: 1})
+	})
 }